@@ -24,6 +24,10 @@ import (
 type BMCSecretSyncStatusSpec struct {
 	// BMCSecretRef references the BMCSecret being tracked
 	BMCSecretRef string `json:"bmcSecretRef"`
+
+	// ConfigRef references the SecretBackendConfig the BMCSecret is synced with
+	// +optional
+	ConfigRef string `json:"configRef,omitempty"`
 }
 
 // BackendPath represents a single backend path that was synced
@@ -31,6 +35,27 @@ type BackendPath struct {
 	// Path is the full path in the backend where the secret is stored
 	Path string `json:"path"`
 
+	// Engine is the name of the secret engine the path belongs to
+	// Empty when no secret engines are configured
+	// +optional
+	Engine string `json:"engine,omitempty"`
+
+	// Namespace is the Vault Enterprise or OpenBao namespace the path is stored in
+	// Empty for the root namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Address is the URL of the server the path is stored on
+	// Empty for paths recorded before their location was
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// MountPath is the KV mount the path is stored in
+	// Empty for paths recorded before their location was, which are taken
+	// to be stored at the current location of their engine
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
 	// BMCName is the name of the BMC resource associated with this path
 	BMCName string `json:"bmcName"`
 
@@ -46,13 +71,33 @@ type BackendPath struct {
 	// LastSyncTime is the timestamp when this path was last synced
 	LastSyncTime metav1.Time `json:"lastSyncTime"`
 
-	// SyncStatus indicates if the sync was successful
-	// +kubebuilder:validation:Enum=Success;Failed
+	// SyncStatus indicates if the sync was successful. Conflict means the path
+	// holds a secret the BMCSecret does not own, which was left untouched
+	// +kubebuilder:validation:Enum=Success;Failed;Conflict
 	SyncStatus string `json:"syncStatus"`
 
 	// ErrorMessage contains the error if sync failed
 	// +optional
 	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// ContentHash is a keyed, salted hash of the data last written to or verified at the
+	// path. It lets the operator detect changes without reading the secret back
+	// +optional
+	ContentHash string `json:"contentHash,omitempty"`
+
+	// LastVerifiedTime is the timestamp when the data at the path was last read
+	// back from the backend and compared
+	// +optional
+	LastVerifiedTime *metav1.Time `json:"lastVerifiedTime,omitempty"`
+
+	// Version is the KV v2 version of the secret last written to or verified at
+	// the path. Not set on KV v1 mounts, which keep no versions
+	// +optional
+	Version int `json:"version,omitempty"`
+
+	// VersionCreatedTime is the timestamp when that version was created in the backend
+	// +optional
+	VersionCreatedTime *metav1.Time `json:"versionCreatedTime,omitempty"`
 }
 
 // BMCSecretSyncStatusStatus defines the observed state of BMCSecretSyncStatus
@@ -61,6 +106,12 @@ type BMCSecretSyncStatusStatus struct {
 	// +optional
 	BackendPaths []BackendPath `json:"backendPaths,omitempty"`
 
+	// OrphanedPaths lists previously synced backend paths that are no longer desired
+	// but still exist in the backend, either because the orphan policy is Report
+	// or because deleting them failed
+	// +optional
+	OrphanedPaths []BackendPath `json:"orphanedPaths,omitempty"`
+
 	// LastSyncAttempt is the timestamp of the last sync attempt
 	// +optional
 	LastSyncAttempt metav1.Time `json:"lastSyncAttempt,omitempty"`
@@ -83,6 +134,7 @@ type BMCSecretSyncStatusStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:printcolumn:name="BMCSecret",type=string,JSONPath=`.spec.bmcSecretRef`
+// +kubebuilder:printcolumn:name="Config",type=string,JSONPath=`.spec.configRef`
 // +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalPaths`
 // +kubebuilder:printcolumn:name="Successful",type=integer,JSONPath=`.status.successfulPaths`
 // +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedPaths`
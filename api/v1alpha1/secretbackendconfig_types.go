@@ -39,7 +39,9 @@ type SecretBackendConfigSpec struct {
 	OpenBaoConfig *OpenBaoConfig `json:"openBaoConfig,omitempty"`
 
 	// PathTemplate is the template string for building secret paths
-	// Available variables: {{.Region}}, {{.Hostname}}, {{.Username}}
+	// Available variables: {{.Region}}, {{.Hostname}}, {{.Username}}, {{.BMCName}}, {{.SecretName}},
+	// {{.Labels}}, {{.Annotations}}, {{.Protocol}}, {{.Port}}, {{.MACAddress}}, {{.IP}}
+	// Available functions: lower, upper, replace, trimSuffix, split, index, default, regexReplace
 	// +kubebuilder:default="bmc/{{.Region}}/{{.Hostname}}/{{.Username}}"
 	// +optional
 	PathTemplate string `json:"pathTemplate,omitempty"`
@@ -49,10 +51,71 @@ type SecretBackendConfigSpec struct {
 	// +optional
 	RegionLabelKey string `json:"regionLabelKey,omitempty"`
 
-	// SyncLabel is the label key that must be present on BMCSecrets to enable syncing
-	// If not specified, all BMCSecrets will be synced
+	// SyncSelector selects the BMCSecrets to sync by their labels
+	// If neither SyncSelector nor SyncLabel is specified, all BMCSecrets will be synced
+	// +optional
+	SyncSelector *metav1.LabelSelector `json:"syncSelector,omitempty"`
+
+	// SyncLabel is a label selector in string form, e.g. "sync" or "team in (a,b),env!=lab",
+	// that BMCSecrets must match to enable syncing. It is ignored if SyncSelector is set.
+	// Deprecated: use SyncSelector instead.
 	// +optional
 	SyncLabel string `json:"syncLabel,omitempty"`
+
+	// OrphanPolicy controls backend paths that were synced before but are no longer
+	// desired, e.g. after a BMC changed its hostname or region or stopped referencing
+	// the BMCSecret. Delete removes them from the backend, Retain leaves them in place
+	// and Report leaves them in place but lists them in the BMCSecretSyncStatus
+	// +kubebuilder:validation:Enum=Delete;Retain;Report
+	// +kubebuilder:default="Delete"
+	// +optional
+	OrphanPolicy string `json:"orphanPolicy,omitempty"`
+
+	// ClusterID identifies this cluster in the ownership marker the operator stores
+	// with every written secret, next to the BMCSecret and config name. Set it to a
+	// unique value when several clusters write to the same backend
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// AdoptPolicy controls existing backend secrets without an ownership marker, e.g.
+	// written by hand or by another system. Refuse leaves them untouched and reports
+	// a conflict in the BMCSecretSyncStatus, Adopt overwrites and deletes them.
+	// Secrets marked as owned by another cluster or BMCSecret are never modified
+	// +kubebuilder:validation:Enum=Refuse;Adopt
+	// +kubebuilder:default="Refuse"
+	// +optional
+	AdoptPolicy string `json:"adoptPolicy,omitempty"`
+
+	// DeletionPolicy controls how secrets are removed from the backend when a
+	// BMCSecret is deleted or a path is pruned as orphaned. Retain leaves the secret
+	// in place, SoftDelete deletes the latest KV v2 version so it can be recovered,
+	// and Destroy permanently removes all versions and the metadata. On KV v1 mounts
+	// SoftDelete behaves like Destroy
+	// +kubebuilder:validation:Enum=Retain;SoftDelete;Destroy
+	// +kubebuilder:default="Destroy"
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// MaxConcurrentSyncs is the maximum number of backend paths synced in parallel
+	// per secret engine while reconciling a BMCSecret
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// +optional
+	MaxConcurrentSyncs int32 `json:"maxConcurrentSyncs,omitempty"`
+
+	// VerificationInterval is how often synced secrets are read back from the backend
+	// and compared in full. In between, changes are detected by comparing a hash of the
+	// BMCSecret data with the one recorded in the BMCSecretSyncStatus, without reading
+	// the secret. Set to 0s to read back on every reconciliation
+	// +kubebuilder:default="24h"
+	// +optional
+	VerificationInterval *metav1.Duration `json:"verificationInterval,omitempty"`
+
+	// ContentHashKeyRef references the key of the HMAC recorded as hash of the BMCSecret
+	// data, so the hash cannot be brute forced without the key. Without it, a key only
+	// kept in memory is used and every secret is read back once after a restart
+	// +optional
+	ContentHashKeyRef *SecretReference `json:"contentHashKeyRef,omitempty"`
 }
 
 // VaultConfig defines Vault-specific configuration
@@ -61,6 +124,11 @@ type VaultConfig struct {
 	// +kubebuilder:validation:Required
 	Address string `json:"address"`
 
+	// Namespace is the Vault Enterprise or HCP Vault namespace to authenticate and
+	// store secrets in (e.g., "admin/team-a"). Empty uses the root namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
 	// AuthMethod specifies the authentication method (kubernetes, token, approle)
 	// +kubebuilder:validation:Enum=kubernetes;token;approle
 	// +kubebuilder:default="kubernetes"
@@ -75,6 +143,10 @@ type VaultConfig struct {
 	// +optional
 	TokenAuth *TokenAuthConfig `json:"tokenAuth,omitempty"`
 
+	// AppRoleAuth contains AppRole auth configuration
+	// +optional
+	AppRoleAuth *AppRoleAuthConfig `json:"appRoleAuth,omitempty"`
+
 	// MountPath is the KV secrets engine mount path
 	// +kubebuilder:default="secret"
 	// +optional
@@ -105,18 +177,64 @@ type SecretEngineConfig struct {
 	MountPath string `json:"mountPath"`
 
 	// PathTemplate is the template string for building secret paths
-	// Available variables: {{.Region}}, {{.Hostname}}, {{.Username}}
+	// Available variables: {{.Region}}, {{.Hostname}}, {{.Username}}, {{.BMCName}}, {{.SecretName}},
+	// {{.Labels}}, {{.Annotations}}, {{.Protocol}}, {{.Port}}, {{.MACAddress}}, {{.IP}}
+	// Available functions: lower, upper, replace, trimSuffix, split, index, default, regexReplace
 	// +kubebuilder:default="bmc/{{.Region}}/{{.Hostname}}/{{.Username}}"
 	// +optional
 	PathTemplate string `json:"pathTemplate,omitempty"`
 
-	// SyncLabel is the label key that must be present on BMCSecrets to sync to this engine
-	// Format: key or key=value. If only key is specified, any value matches.
+	// SyncSelector selects the BMCSecrets to sync to this engine by their labels
+	// An empty selector matches all BMCSecrets. Either SyncSelector or SyncLabel is required.
+	// +optional
+	SyncSelector *metav1.LabelSelector `json:"syncSelector,omitempty"`
+
+	// SyncLabel is a label selector in string form that BMCSecrets must match to sync to this engine
+	// It is ignored if SyncSelector is set.
 	// Example: "team=a" will match BMCSecrets with label team=a
 	// Example: "sync-to-vault" will match BMCSecrets with any value for sync-to-vault label
-	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:MinLength=1
-	SyncLabel string `json:"syncLabel"`
+	// Deprecated: use SyncSelector instead.
+	// +optional
+	SyncLabel string `json:"syncLabel,omitempty"`
+
+	// MaxConcurrentSyncs overrides the maximum number of paths synced in parallel to this engine
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentSyncs *int32 `json:"maxConcurrentSyncs,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the SecretBackendConfig for this engine
+	// +kubebuilder:validation:Enum=Retain;SoftDelete;Destroy
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Address overrides the server URL, for engines on a separate cluster
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Namespace overrides the namespace to authenticate and store secrets in
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// AuthMethod overrides the authentication method (kubernetes, token, approle)
+	// +kubebuilder:validation:Enum=kubernetes;token;approle
+	// +optional
+	AuthMethod string `json:"authMethod,omitempty"`
+
+	// KubernetesAuth overrides the Kubernetes auth configuration
+	// +optional
+	KubernetesAuth *KubernetesAuthConfig `json:"kubernetesAuth,omitempty"`
+
+	// TokenAuth overrides the token auth configuration
+	// +optional
+	TokenAuth *TokenAuthConfig `json:"tokenAuth,omitempty"`
+
+	// AppRoleAuth overrides the AppRole auth configuration
+	// +optional
+	AppRoleAuth *AppRoleAuthConfig `json:"appRoleAuth,omitempty"`
+
+	// TLSConfig overrides the TLS configuration
+	// +optional
+	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
 }
 
 // KubernetesAuthConfig defines Kubernetes authentication configuration
@@ -138,6 +256,23 @@ type TokenAuthConfig struct {
 	SecretRef SecretReference `json:"secretRef"`
 }
 
+// AppRoleAuthConfig defines AppRole authentication configuration
+type AppRoleAuthConfig struct {
+	// RoleID is the AppRole role ID
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	RoleID string `json:"roleID"`
+
+	// SecretIDRef references a Kubernetes secret containing the AppRole secret ID
+	// +kubebuilder:validation:Required
+	SecretIDRef SecretReference `json:"secretIDRef"`
+
+	// Path is the AppRole auth mount path
+	// +kubebuilder:default="approle"
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
 // SecretReference defines a reference to a Kubernetes secret
 type SecretReference struct {
 	// Name is the name of the secret
@@ -165,15 +300,49 @@ type TLSConfig struct {
 	CACert string `json:"caCert,omitempty"`
 }
 
-// OpenBaoConfig defines OpenBao-specific configuration (future)
+// OpenBaoConfig defines OpenBao-specific configuration
+// OpenBao is API-compatible with Vault, so the fields mirror VaultConfig
 type OpenBaoConfig struct {
 	// Address is the OpenBao server URL
 	// +kubebuilder:validation:Required
 	Address string `json:"address"`
 
-	// Configuration fields will mirror VaultConfig
+	// Namespace is the OpenBao namespace to authenticate and store secrets in
+	// (e.g., "team-a"). Empty uses the root namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// AuthMethod specifies the authentication method (kubernetes, token, approle)
+	// +kubebuilder:validation:Enum=kubernetes;token;approle
+	// +kubebuilder:default="kubernetes"
 	// +optional
 	AuthMethod string `json:"authMethod,omitempty"`
+
+	// KubernetesAuth contains Kubernetes auth configuration
+	// +optional
+	KubernetesAuth *KubernetesAuthConfig `json:"kubernetesAuth,omitempty"`
+
+	// TokenAuth contains token auth configuration
+	// +optional
+	TokenAuth *TokenAuthConfig `json:"tokenAuth,omitempty"`
+
+	// AppRoleAuth contains AppRole auth configuration
+	// +optional
+	AppRoleAuth *AppRoleAuthConfig `json:"appRoleAuth,omitempty"`
+
+	// MountPath is the KV secrets engine mount path
+	// +kubebuilder:default="secret"
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// TLSConfig contains TLS configuration
+	// +optional
+	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
+
+	// SecretEngines contains a list of secret engine configurations for different teams/purposes
+	// Each entry can specify a different mount path, path template, and sync label
+	// +optional
+	SecretEngines []SecretEngineConfig `json:"secretEngines,omitempty"`
 }
 
 // SecretBackendConfigStatus defines the observed state of SecretBackendConfig.
@@ -197,11 +366,50 @@ type SecretBackendConfigStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the conditions were determined for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastProbeTime is when the operator last connected to the backends
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// Engines holds the status of every secret engine
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Engines []SecretEngineStatus `json:"engines,omitempty"`
+}
+
+// SecretEngineStatus is the observed state of a secret engine
+type SecretEngineStatus struct {
+	// Name is the name of the secret engine
+	Name string `json:"name"`
+
+	// MountPath is the KV secrets engine mount path of the engine
+	MountPath string `json:"mountPath"`
+
+	// Ready indicates whether the operator authenticated with the engine and found its mount
+	Ready bool `json:"ready"`
+
+	// Reason is a CamelCase reason for the state of the engine
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message describes why the engine is not ready
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Backend",type=string,JSONPath=`.spec.backend`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Authenticated",type=string,JSONPath=`.status.conditions[?(@.type=="Authenticated")].status`
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // SecretBackendConfig is the Schema for the secretbackendconfigs API
 type SecretBackendConfig struct {
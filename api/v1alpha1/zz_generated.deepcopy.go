@@ -25,6 +25,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppRoleAuthConfig) DeepCopyInto(out *AppRoleAuthConfig) {
+	*out = *in
+	out.SecretIDRef = in.SecretIDRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppRoleAuthConfig.
+func (in *AppRoleAuthConfig) DeepCopy() *AppRoleAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AppRoleAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BMCSecretSyncStatus) DeepCopyInto(out *BMCSecretSyncStatus) {
 	*out = *in
@@ -109,6 +125,13 @@ func (in *BMCSecretSyncStatusStatus) DeepCopyInto(out *BMCSecretSyncStatusStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OrphanedPaths != nil {
+		in, out := &in.OrphanedPaths, &out.OrphanedPaths
+		*out = make([]BackendPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.LastSyncAttempt.DeepCopyInto(&out.LastSyncAttempt)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -133,6 +156,14 @@ func (in *BMCSecretSyncStatusStatus) DeepCopy() *BMCSecretSyncStatusStatus {
 func (in *BackendPath) DeepCopyInto(out *BackendPath) {
 	*out = *in
 	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+	if in.LastVerifiedTime != nil {
+		in, out := &in.LastVerifiedTime, &out.LastVerifiedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.VersionCreatedTime != nil {
+		in, out := &in.VersionCreatedTime, &out.VersionCreatedTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendPath.
@@ -163,6 +194,33 @@ func (in *KubernetesAuthConfig) DeepCopy() *KubernetesAuthConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenBaoConfig) DeepCopyInto(out *OpenBaoConfig) {
 	*out = *in
+	if in.KubernetesAuth != nil {
+		in, out := &in.KubernetesAuth, &out.KubernetesAuth
+		*out = new(KubernetesAuthConfig)
+		**out = **in
+	}
+	if in.TokenAuth != nil {
+		in, out := &in.TokenAuth, &out.TokenAuth
+		*out = new(TokenAuthConfig)
+		**out = **in
+	}
+	if in.AppRoleAuth != nil {
+		in, out := &in.AppRoleAuth, &out.AppRoleAuth
+		*out = new(AppRoleAuthConfig)
+		**out = **in
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
+		**out = **in
+	}
+	if in.SecretEngines != nil {
+		in, out := &in.SecretEngines, &out.SecretEngines
+		*out = make([]SecretEngineConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenBaoConfig.
@@ -245,6 +303,21 @@ func (in *SecretBackendConfigSpec) DeepCopyInto(out *SecretBackendConfigSpec) {
 	if in.OpenBaoConfig != nil {
 		in, out := &in.OpenBaoConfig, &out.OpenBaoConfig
 		*out = new(OpenBaoConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncSelector != nil {
+		in, out := &in.SyncSelector, &out.SyncSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerificationInterval != nil {
+		in, out := &in.VerificationInterval, &out.VerificationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ContentHashKeyRef != nil {
+		in, out := &in.ContentHashKeyRef, &out.ContentHashKeyRef
+		*out = new(SecretReference)
 		**out = **in
 	}
 }
@@ -269,6 +342,15 @@ func (in *SecretBackendConfigStatus) DeepCopyInto(out *SecretBackendConfigStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Engines != nil {
+		in, out := &in.Engines, &out.Engines
+		*out = make([]SecretEngineStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretBackendConfigStatus.
@@ -284,6 +366,36 @@ func (in *SecretBackendConfigStatus) DeepCopy() *SecretBackendConfigStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretEngineConfig) DeepCopyInto(out *SecretEngineConfig) {
 	*out = *in
+	if in.SyncSelector != nil {
+		in, out := &in.SyncSelector, &out.SyncSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxConcurrentSyncs != nil {
+		in, out := &in.MaxConcurrentSyncs, &out.MaxConcurrentSyncs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KubernetesAuth != nil {
+		in, out := &in.KubernetesAuth, &out.KubernetesAuth
+		*out = new(KubernetesAuthConfig)
+		**out = **in
+	}
+	if in.TokenAuth != nil {
+		in, out := &in.TokenAuth, &out.TokenAuth
+		*out = new(TokenAuthConfig)
+		**out = **in
+	}
+	if in.AppRoleAuth != nil {
+		in, out := &in.AppRoleAuth, &out.AppRoleAuth
+		*out = new(AppRoleAuthConfig)
+		**out = **in
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEngineConfig.
@@ -296,6 +408,21 @@ func (in *SecretEngineConfig) DeepCopy() *SecretEngineConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretEngineStatus) DeepCopyInto(out *SecretEngineStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEngineStatus.
+func (in *SecretEngineStatus) DeepCopy() *SecretEngineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretEngineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -355,6 +482,11 @@ func (in *VaultConfig) DeepCopyInto(out *VaultConfig) {
 		*out = new(TokenAuthConfig)
 		**out = **in
 	}
+	if in.AppRoleAuth != nil {
+		in, out := &in.AppRoleAuth, &out.AppRoleAuth
+		*out = new(AppRoleAuthConfig)
+		**out = **in
+	}
 	if in.TLSConfig != nil {
 		in, out := &in.TLSConfig, &out.TLSConfig
 		*out = new(TLSConfig)
@@ -363,7 +495,9 @@ func (in *VaultConfig) DeepCopyInto(out *VaultConfig) {
 	if in.SecretEngines != nil {
 		in, out := &in.SecretEngines, &out.SecretEngines
 		*out = make([]SecretEngineConfig, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
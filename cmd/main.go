@@ -19,18 +19,24 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"net/http"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -39,6 +45,10 @@ import (
 	"github.com/ironcore-dev/bmc-secret-operator/internal/controller"
 	"github.com/ironcore-dev/bmc-secret-operator/internal/metrics"
 	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
+	// Register the Vault and OpenBao secret backends
+	_ "github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/openbao"
+	_ "github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/vault"
+	webhookconfigv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/internal/webhook/v1alpha1"
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
@@ -65,6 +75,8 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var probeInterval time.Duration
+	var readinessInterval time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -83,6 +95,10 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&probeInterval, "backend-probe-interval", 5*time.Minute,
+		"How often the backends of every SecretBackendConfig are probed to refresh its conditions.")
+	flag.DurationVar(&readinessInterval, "backend-readiness-interval", secretbackend.DefaultReadinessInterval,
+		"How often the secret backends are checked to report the readiness of the operator.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -163,10 +179,17 @@ func main() {
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
-		Metrics:                metricsServerOptions,
-		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
+		Scheme:        scheme,
+		Metrics:       metricsServerOptions,
+		WebhookServer: webhookServer,
+		// Secrets are read from the API server, so the data of secrets unrelated to
+		// the operator is never cached. Only the metadata of secrets is watched.
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}},
+		},
+		// The health probes are served by newHealthProbeServer, which adds the
+		// readiness detail of every secret backend
+		HealthProbeBindAddress: "0",
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "bd36b7a2.metal.ironcore.dev",
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
@@ -186,26 +209,30 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Initialize backend factory
-	backendFactory, err := secretbackend.NewBackendFactory(mgr.GetClient(), metricsCollector)
+	// Initialize backend registry with a backend factory per SecretBackendConfig
+	backendRegistry, err := secretbackend.NewBackendRegistry(mgr.GetClient(), metricsCollector)
 	if err != nil {
-		setupLog.Error(err, "unable to create backend factory")
+		setupLog.Error(err, "unable to create backend registry")
 		os.Exit(1)
 	}
 	defer func() {
-		if err := backendFactory.Close(); err != nil {
-			setupLog.Error(err, "failed to close backend factory")
+		if err := backendRegistry.Close(); err != nil {
+			setupLog.Error(err, "failed to close backend registry")
 		}
 	}()
 
+	// Configuration changes re-enqueue all BMCSecrets
+	configChanges := make(chan event.GenericEvent, 1)
+
 	// Setup BMCSecret controller
 	//nolint:staticcheck // TODO: migrate to new events API
 	if err = (&controller.BMCSecretReconciler{
-		Client:         mgr.GetClient(),
-		Scheme:         mgr.GetScheme(),
-		Recorder:       mgr.GetEventRecorderFor("bmcsecret-controller"),
-		BackendFactory: backendFactory,
-		Metrics:        metricsCollector,
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		Recorder:      mgr.GetEventRecorderFor("bmcsecret-controller"),
+		Registry:      backendRegistry,
+		Metrics:       metricsCollector,
+		ConfigChanges: configChanges,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BMCSecret")
 		os.Exit(1)
@@ -214,24 +241,39 @@ func main() {
 	// Setup SecretBackendConfig controller to watch for configuration changes
 	//nolint:staticcheck // TODO: migrate to new events API
 	if err = (&controller.SecretBackendConfigReconciler{
-		Client:         mgr.GetClient(),
-		Scheme:         mgr.GetScheme(),
-		Recorder:       mgr.GetEventRecorderFor("secretbackendconfig-controller"),
-		BackendFactory: backendFactory,
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		Recorder:      mgr.GetEventRecorderFor("secretbackendconfig-controller"),
+		Registry:      backendRegistry,
+		ConfigChanges: configChanges,
+		Prober:        secretbackend.NewBackendProber(mgr.GetClient(), metricsCollector),
+		ProbeInterval: probeInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SecretBackendConfig")
 		os.Exit(1)
 	}
 
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookconfigv1alpha1.SetupSecretBackendConfigWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SecretBackendConfig")
+			os.Exit(1)
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 
-	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up health check")
+	// Check the secret backends in the background, so readiness probes stay cheap
+	backendReadiness := secretbackend.NewReadinessChecker(backendRegistry, readinessInterval)
+	if err := mgr.Add(backendReadiness); err != nil {
+		setupLog.Error(err, "unable to set up backend readiness checks")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+	if probeAddr != "0" {
+		if err := mgr.Add(newHealthProbeServer(probeAddr, backendReadiness)); err != nil {
+			setupLog.Error(err, "unable to set up health probes")
+			os.Exit(1)
+		}
 	}
 
 	setupLog.Info("starting manager")
@@ -240,3 +282,27 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// newHealthProbeServer serves the liveness probe on /healthz and the readiness probe on
+// /readyz, which fails while a secret backend is unreachable or rejects the credentials.
+// /readyz/verbose lists the readiness of every backend with the reason of failures.
+func newHealthProbeServer(addr string, backendReadiness *secretbackend.ReadinessChecker) *manager.Server {
+	liveness := &healthz.Handler{Checks: map[string]healthz.Checker{"healthz": healthz.Ping}}
+	readiness := &healthz.Handler{Checks: map[string]healthz.Checker{"secret-backends": backendReadiness.Check}}
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", http.StripPrefix("/healthz", liveness))
+	mux.Handle("/healthz/", http.StripPrefix("/healthz", liveness))
+	mux.Handle("/readyz", http.StripPrefix("/readyz", readiness))
+	mux.Handle("/readyz/", http.StripPrefix("/readyz", readiness))
+	mux.Handle("/readyz/verbose", backendReadiness)
+
+	return &manager.Server{
+		Name: "health probe",
+		Server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
@@ -24,21 +24,32 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// FindBMCsForSecret returns all BMC resources referencing the given BMCSecret name
+// BMCSecretRefField is the field index on the BMCSecret name referenced by a BMC
+const BMCSecretRefField = "spec.bmcSecretRef.name"
+
+// IndexBMCSecretRef returns the BMCSecret name referenced by a BMC for the BMCSecretRefField index
+func IndexBMCSecretRef(obj client.Object) []string {
+	bmc, ok := obj.(*metalv1alpha1.BMC)
+	if !ok || bmc.Spec.BMCSecretRef.Name == "" {
+		return nil
+	}
+	return []string{bmc.Spec.BMCSecretRef.Name}
+}
+
+// SetupBMCSecretRefIndex registers the BMCSecretRefField index with the field indexer
+func SetupBMCSecretRefIndex(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &metalv1alpha1.BMC{}, BMCSecretRefField, IndexBMCSecretRef)
+}
+
+// FindBMCsForSecret returns all BMC resources referencing the given BMCSecret name.
+// Requires the BMCSecretRefField index to be registered.
 func FindBMCsForSecret(ctx context.Context, c client.Client, secretName string) ([]metalv1alpha1.BMC, error) {
 	var bmcList metalv1alpha1.BMCList
-	if err := c.List(ctx, &bmcList); err != nil {
+	if err := c.List(ctx, &bmcList, client.MatchingFields{BMCSecretRefField: secretName}); err != nil {
 		return nil, fmt.Errorf("failed to list BMC resources: %w", err)
 	}
 
-	var matchingBMCs []metalv1alpha1.BMC
-	for _, bmc := range bmcList.Items {
-		if bmc.Spec.BMCSecretRef.Name == secretName {
-			matchingBMCs = append(matchingBMCs, bmc)
-		}
-	}
-
-	return matchingBMCs, nil
+	return bmcList.Items, nil
 }
 
 // ExtractRegionFromBMC gets the region from BMC labels using configurable key
@@ -69,3 +80,23 @@ func GetHostnameFromBMC(bmc *metalv1alpha1.BMC) string {
 	// Fallback to BMC name
 	return bmc.Name
 }
+
+// GetAddressFromBMC returns the MAC and IP address of the BMC from its inline endpoint,
+// falling back to the address reported in its status. Unknown addresses are empty.
+func GetAddressFromBMC(bmc *metalv1alpha1.BMC) (macAddress, ip string) {
+	if bmc.Spec.Endpoint != nil {
+		macAddress = bmc.Spec.Endpoint.MACAddress
+		if bmc.Spec.Endpoint.IP.IsValid() {
+			ip = bmc.Spec.Endpoint.IP.String()
+		}
+	}
+
+	if macAddress == "" {
+		macAddress = bmc.Status.MACAddress
+	}
+	if ip == "" && bmc.Status.IP.IsValid() {
+		ip = bmc.Status.IP.String()
+	}
+
+	return macAddress, ip
+}
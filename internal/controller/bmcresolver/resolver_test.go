@@ -47,7 +47,10 @@ var _ = Describe("BMCResolver", func() {
 		ctx = context.Background()
 		scheme = runtime.NewScheme()
 		Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
-		k8sClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		k8sClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithIndex(&metalv1alpha1.BMC{}, BMCSecretRefField, IndexBMCSecretRef).
+			Build()
 	})
 
 	Context("FindBMCsForSecret", func() {
@@ -122,6 +125,11 @@ var _ = Describe("BMCResolver", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(bmcs).To(BeEmpty())
 		})
+
+		It("Should not index BMCs without a secret reference", func() {
+			Expect(IndexBMCSecretRef(&metalv1alpha1.BMC{})).To(BeEmpty())
+			Expect(IndexBMCSecretRef(&metalv1alpha1.BMCSecret{})).To(BeEmpty())
+		})
 	})
 
 	Context("ExtractRegionFromBMC", func() {
@@ -242,6 +250,58 @@ var _ = Describe("BMCResolver", func() {
 		})
 	})
 
+	Context("GetAddressFromBMC", func() {
+		It("Should extract the address from the inline endpoint", func() {
+			bmc := &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-bmc",
+				},
+				Spec: metalv1alpha1.BMCSpec{
+					Endpoint: &metalv1alpha1.InlineEndpoint{
+						MACAddress: "aa:bb:cc:dd:ee:ff",
+						IP:         metalv1alpha1.MustParseIP("10.0.0.1"),
+					},
+				},
+				Status: metalv1alpha1.BMCStatus{
+					MACAddress: "11:22:33:44:55:66",
+					IP:         metalv1alpha1.MustParseIP("10.0.0.2"),
+				},
+			}
+
+			macAddress, ip := GetAddressFromBMC(bmc)
+			Expect(macAddress).To(Equal("aa:bb:cc:dd:ee:ff"))
+			Expect(ip).To(Equal("10.0.0.1"))
+		})
+
+		It("Should fallback to the address in the status", func() {
+			bmc := &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-bmc",
+				},
+				Status: metalv1alpha1.BMCStatus{
+					MACAddress: "11:22:33:44:55:66",
+					IP:         metalv1alpha1.MustParseIP("10.0.0.2"),
+				},
+			}
+
+			macAddress, ip := GetAddressFromBMC(bmc)
+			Expect(macAddress).To(Equal("11:22:33:44:55:66"))
+			Expect(ip).To(Equal("10.0.0.2"))
+		})
+
+		It("Should return empty strings when the address is unknown", func() {
+			bmc := &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-bmc",
+				},
+			}
+
+			macAddress, ip := GetAddressFromBMC(bmc)
+			Expect(macAddress).To(BeEmpty())
+			Expect(ip).To(BeEmpty())
+		})
+	})
+
 	Context("ExtractCredentials", func() {
 		It("Should extract username and password from BMCSecret", func() {
 			bmcSecret := &metalv1alpha1.BMCSecret{
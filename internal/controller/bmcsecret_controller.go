@@ -18,23 +18,39 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	goerrors "errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/ironcore-dev/bmc-secret-operator/internal/controller/bmcresolver"
 	"github.com/ironcore-dev/bmc-secret-operator/internal/metrics"
@@ -42,14 +58,22 @@ import (
 )
 
 const (
-	bmcSecretFinalizer = "bmcsecret.metal.ironcore.dev/backend-cleanup"
-	requeueAfterNormal = 5 * time.Minute
-	requeueAfterError  = 30 * time.Second
+	bmcSecretFinalizer   = "bmcsecret.metal.ironcore.dev/backend-cleanup"
+	requeueAfterNormal   = 5 * time.Minute
+	requeueAfterError    = 30 * time.Second
+	requeueAfterConflict = 5 * time.Second
 
 	reconcileResultSuccess = "success"
 	reconcileResultError   = "error"
 )
 
+// syncStatusBMCSecretLabel and syncStatusConfigLabel identify the BMCSecret and the
+// configuration a BMCSecretSyncStatus records the sync of
+const (
+	syncStatusBMCSecretLabel = "bmcsecret.metal.ironcore.dev/bmcsecret"
+	syncStatusConfigLabel    = "bmcsecret.metal.ironcore.dev/config"
+)
+
 // BMCSecretReconciler reconciles a BMCSecret object
 type BMCSecretReconciler struct {
 	client.Client
@@ -57,6 +81,19 @@ type BMCSecretReconciler struct {
 	Recorder       record.EventRecorder
 	BackendFactory secretbackend.BackendFactoryInterface
 	Metrics        *metrics.Collector
+
+	// Registry provides a backend factory per SecretBackendConfig. BMCSecrets are
+	// synced with every configuration whose sync selector matches. Without a
+	// registry, BackendFactory is the only configuration.
+	Registry secretbackend.BackendRegistryInterface
+
+	// configName is the configuration BackendFactory belongs to, set on the
+	// per-configuration copies returned by configReconcilers
+	configName string
+
+	// ConfigChanges receives an event whenever the backend configuration changes,
+	// re-enqueueing all BMCSecrets so the new sync scope and paths are applied
+	ConfigChanges <-chan event.GenericEvent
 }
 
 // +kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcsecrets,verbs=get;list;watch
@@ -67,7 +104,7 @@ type BMCSecretReconciler struct {
 // +kubebuilder:rbac:groups=config.metal.ironcore.dev,resources=bmcsecretsyncstatuses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=config.metal.ironcore.dev,resources=bmcsecretsyncstatuses/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop
 func (r *BMCSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -99,25 +136,64 @@ func (r *BMCSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
-	// Check if secret should be synced based on label
-	syncLabel, err := r.BackendFactory.GetSyncLabel(ctx)
+	configs, err := r.configReconcilers(ctx)
 	if err != nil {
-		logger.Error(err, "Failed to get sync label configuration")
+		logger.Error(err, "Failed to get backend configurations")
 		reconcileErr = err
 		return ctrl.Result{RequeueAfter: requeueAfterError}, err
 	}
 
-	// If sync label is configured, check if the BMCSecret has it
-	if syncLabel != "" {
-		if bmcSecret.Labels == nil || bmcSecret.Labels[syncLabel] == "" {
-			logger.V(1).Info("BMCSecret does not have required sync label, skipping", "syncLabel", syncLabel)
-			return ctrl.Result{}, nil
+	// Handle deletion, also for BMCSecrets that left the sync scope after being synced
+	if !bmcSecret.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, &bmcSecret, configs)
+	}
+
+	// Check which configurations sync the secret based on its labels
+	var (
+		inScope []*BMCSecretReconciler
+		tracked bool
+		errs    []error
+	)
+	for _, config := range configs {
+		configCtx := config.logContext(ctx)
+		syncSelector, err := config.BackendFactory.GetSyncSelector(configCtx)
+		if err != nil {
+			log.FromContext(configCtx).Error(err, "Failed to get sync selector configuration")
+			errs = append(errs, err)
+			// The paths synced with the configuration are kept until it is fixed
+			tracked = true
+			continue
+		}
+
+		if syncSelector.Matches(labels.Set(bmcSecret.Labels)) {
+			inScope = append(inScope, config)
+			continue
+		}
+
+		log.FromContext(configCtx).V(1).Info("BMCSecret does not match the sync selector", "syncSelector", syncSelector.String())
+		if !controllerutil.ContainsFinalizer(&bmcSecret, bmcSecretFinalizer) {
+			continue
+		}
+		released, err := config.releaseOutOfScope(configCtx, &bmcSecret)
+		if err != nil {
+			errs = append(errs, err)
 		}
+		tracked = tracked || !released
 	}
 
-	// Handle deletion
-	if !bmcSecret.DeletionTimestamp.IsZero() {
-		return r.handleDeletion(ctx, &bmcSecret)
+	if len(inScope) == 0 {
+		if len(errs) > 0 {
+			reconcileErr = goerrors.Join(errs...)
+			return ctrl.Result{RequeueAfter: requeueAfterError}, reconcileErr
+		}
+		if tracked {
+			return ctrl.Result{RequeueAfter: requeueAfterNormal}, nil
+		}
+		if err := r.removeFinalizer(ctx, &bmcSecret); err != nil {
+			reconcileErr = err
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
 	}
 
 	// Add finalizer if not present
@@ -131,7 +207,7 @@ func (r *BMCSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	// Discover BMCs that reference this secret
-	bmcs, err := bmcresolver.FindBMCsForSecret(ctx, r.Client, bmcSecret.Name)
+	bmcs, err := r.findBMCs(ctx, bmcSecret.Name)
 	if err != nil {
 		logger.Error(err, "Failed to find BMCs for secret")
 		r.Recorder.Event(&bmcSecret, "Warning", "BMCDiscoveryFailed", err.Error())
@@ -146,6 +222,13 @@ func (r *BMCSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if len(bmcs) == 0 {
 		logger.Info("No BMCs reference this secret")
 		r.Recorder.Event(&bmcSecret, "Normal", "NoBMCReference", "No BMCs reference this secret")
+		for _, config := range inScope {
+			config.pruneAllPaths(config.logContext(ctx), &bmcSecret)
+		}
+		if len(errs) > 0 {
+			reconcileErr = goerrors.Join(errs...)
+			return ctrl.Result{RequeueAfter: requeueAfterError}, reconcileErr
+		}
 		return ctrl.Result{RequeueAfter: requeueAfterNormal}, nil
 	}
 
@@ -161,21 +244,176 @@ func (r *BMCSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{RequeueAfter: requeueAfterError}, err
 	}
 
+	// Sync to every matching configuration, requeueing as early as the most urgent one needs
+	result := ctrl.Result{RequeueAfter: requeueAfterNormal}
+	for _, config := range inScope {
+		var configErr error
+		configResult, err := config.reconcileConfig(config.logContext(ctx), &bmcSecret, bmcs, username, password, &configErr)
+		if err != nil {
+			errs = append(errs, err)
+		} else if configErr != nil {
+			errs = append(errs, configErr)
+		}
+		if configResult.RequeueAfter > 0 && configResult.RequeueAfter < result.RequeueAfter {
+			result.RequeueAfter = configResult.RequeueAfter
+		}
+	}
+
+	if len(errs) > 0 {
+		reconcileErr = goerrors.Join(errs...)
+		return ctrl.Result{RequeueAfter: requeueAfterError}, reconcileErr
+	}
+	return result, nil
+}
+
+// reconcileConfig syncs the BMCSecret with the configuration of the reconciler
+func (r *BMCSecretReconciler) reconcileConfig(
+	ctx context.Context,
+	bmcSecret *metalv1alpha1.BMCSecret,
+	bmcs []metalv1alpha1.BMC,
+	username, password string,
+	reconcileErr *error,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
 	// Check if multi-engine configuration exists
 	hasMultiEngine, err := r.BackendFactory.HasMultiEngineConfig(ctx)
 	if err != nil {
 		logger.Error(err, "Failed to check multi-engine configuration")
-		reconcileErr = err
+		*reconcileErr = err
 		return ctrl.Result{RequeueAfter: requeueAfterError}, err
 	}
 
 	if hasMultiEngine {
 		// Use multi-engine sync path
-		return r.reconcileMultiEngine(ctx, &bmcSecret, bmcs, username, password, &reconcileErr)
+		return r.reconcileMultiEngine(ctx, bmcSecret, bmcs, username, password, reconcileErr)
 	}
 
 	// Fall back to single-engine path for backward compatibility
-	return r.reconcileSingleEngine(ctx, &bmcSecret, bmcs, username, password, &reconcileErr)
+	return r.reconcileSingleEngine(ctx, bmcSecret, bmcs, username, password, reconcileErr)
+}
+
+// configReconcilers returns a copy of the reconciler for every backend configuration,
+// each using the backend factory of its configuration, ordered by config name
+func (r *BMCSecretReconciler) configReconcilers(ctx context.Context) ([]*BMCSecretReconciler, error) {
+	if r.Registry == nil {
+		return []*BMCSecretReconciler{r}, nil
+	}
+
+	factories, err := r.Registry.GetFactories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make([]*BMCSecretReconciler, 0, len(factories))
+	for _, name := range slices.Sorted(maps.Keys(factories)) {
+		config := *r
+		config.BackendFactory = factories[name]
+		config.configName = name
+		configs = append(configs, &config)
+	}
+	return configs, nil
+}
+
+// logContext returns ctx with a logger naming the configuration of the reconciler
+func (r *BMCSecretReconciler) logContext(ctx context.Context) context.Context {
+	if r.configName == "" {
+		return ctx
+	}
+	return log.IntoContext(ctx, log.FromContext(ctx).WithValues("config", r.configName))
+}
+
+// syncStatusName returns the name of a new BMCSecretSyncStatus recording the sync of a
+// BMCSecret with the configuration of the reconciler. The default configuration keeps
+// the name used before multiple configurations were supported, other configurations
+// append a hash of the configuration name, so the names of two pairs never match.
+func (r *BMCSecretReconciler) syncStatusName(bmcSecretName string) string {
+	if isDefaultConfig(r.configName) {
+		return fmt.Sprintf("%s-sync-status", bmcSecretName)
+	}
+	return fmt.Sprintf("%s-sync-status-%s", bmcSecretName, nameHash(r.configName))
+}
+
+// legacySyncStatusName returns the name BMCSecretSyncStatuses of other configurations
+// than the default one were created with before they were labeled
+func (r *BMCSecretReconciler) legacySyncStatusName(bmcSecretName string) string {
+	if isDefaultConfig(r.configName) {
+		return fmt.Sprintf("%s-sync-status", bmcSecretName)
+	}
+	return fmt.Sprintf("%s-%s-sync-status", bmcSecretName, r.configName)
+}
+
+// syncStatusLabels returns the labels identifying the BMCSecretSyncStatus of a BMCSecret
+// and the configuration of the reconciler
+func (r *BMCSecretReconciler) syncStatusLabels(bmcSecretName string) map[string]string {
+	return map[string]string{
+		syncStatusBMCSecretLabel: labelValue(bmcSecretName),
+		syncStatusConfigLabel:    labelValue(r.configName),
+	}
+}
+
+// getSyncStatus returns the BMCSecretSyncStatus recording the sync of a BMCSecret with
+// the configuration of the reconciler. It is looked up by its labels, and by the name
+// it was created with for statuses created before they were labeled.
+func (r *BMCSecretReconciler) getSyncStatus(ctx context.Context, bmcSecretName string) (*configv1alpha1.BMCSecretSyncStatus, error) {
+	var syncStatuses configv1alpha1.BMCSecretSyncStatusList
+	if err := r.List(ctx, &syncStatuses, client.MatchingLabels(r.syncStatusLabels(bmcSecretName))); err != nil {
+		return nil, err
+	}
+	for i := range syncStatuses.Items {
+		// Label values of long names are hashed, so compare the names as well
+		if r.recordsSync(&syncStatuses.Items[i], bmcSecretName) {
+			return &syncStatuses.Items[i], nil
+		}
+	}
+
+	legacyName := r.legacySyncStatusName(bmcSecretName)
+	syncStatus := &configv1alpha1.BMCSecretSyncStatus{}
+	if err := r.Get(ctx, types.NamespacedName{Name: legacyName}, syncStatus); err != nil {
+		return nil, err
+	}
+	// The former names were ambiguous, so the status may belong to another pair
+	if _, labeled := syncStatus.Labels[syncStatusBMCSecretLabel]; labeled || !r.recordsSync(syncStatus, bmcSecretName) {
+		return nil, errors.NewNotFound(configv1alpha1.GroupVersion.WithResource("bmcsecretsyncstatuses").GroupResource(), legacyName)
+	}
+	return syncStatus, nil
+}
+
+// recordsSync reports whether the BMCSecretSyncStatus records the sync of the BMCSecret
+// with the configuration of the reconciler
+func (r *BMCSecretReconciler) recordsSync(syncStatus *configv1alpha1.BMCSecretSyncStatus, bmcSecretName string) bool {
+	if syncStatus.Spec.BMCSecretRef != bmcSecretName {
+		return false
+	}
+	configRef := syncStatus.Spec.ConfigRef
+	return configRef == r.configName || isDefaultConfig(configRef) && isDefaultConfig(r.configName)
+}
+
+// isDefaultConfig reports whether the configuration name refers to the default
+// configuration, which syncs BMCSecrets created before multiple configurations
+// were supported
+func isDefaultConfig(configName string) bool {
+	switch configName {
+	case "", secretbackend.DefaultBackendConfigName, secretbackend.EnvConfigName:
+		return true
+	}
+	return false
+}
+
+// labelValue returns name as label value. Names longer than a label value allows
+// are replaced by their hash.
+func labelValue(name string) string {
+	if len(name) <= validation.LabelValueMaxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])[:validation.LabelValueMaxLength]
+}
+
+// nameHash returns a short hash of name for use in object names
+func nameHash(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:4])
 }
 
 // reconcileSingleEngine handles reconciliation for single-engine configuration (backward compatibility)
@@ -205,135 +443,61 @@ func (r *BMCSecretReconciler) reconcileSingleEngine(
 		return ctrl.Result{RequeueAfter: requeueAfterError}, err
 	}
 
-	// Get region label key
-	regionLabelKey, err := r.BackendFactory.GetRegionLabelKey(ctx)
+	maxConcurrentSyncs, err := r.BackendFactory.GetMaxConcurrentSyncs(ctx)
 	if err != nil {
-		logger.Error(err, "Failed to get region label key")
+		logger.Error(err, "Failed to get sync concurrency")
 		*reconcileErr = err
 		return ctrl.Result{RequeueAfter: requeueAfterError}, err
 	}
 
-	// Sync secrets for each BMC and track status
-	syncErrors := 0
-	syncSuccess := 0
-	backendPaths := make([]configv1alpha1.BackendPath, 0, len(bmcs))
-	syncTime := metav1.Now()
-
-	for _, bmc := range bmcs {
-		region := bmcresolver.ExtractRegionFromBMC(&bmc, regionLabelKey)
-		hostname := bmcresolver.GetHostnameFromBMC(&bmc)
-
-		// Build path
-		path, err := pathBuilder.Build(secretbackend.PathVariables{
-			Region:   region,
-			Hostname: hostname,
-			Username: username,
-		})
-		if err != nil {
-			logger.Error(err, "Failed to build path", "bmc", bmc.Name)
-			backendPaths = append(backendPaths, configv1alpha1.BackendPath{
-				Path:         path,
-				BMCName:      bmc.Name,
-				Region:       region,
-				Hostname:     hostname,
-				Username:     username,
-				LastSyncTime: syncTime,
-				SyncStatus:   "Failed",
-				ErrorMessage: err.Error(),
-			})
-			syncErrors++
-			continue
-		}
-
-		// Check if update needed
-		needsUpdate, err := r.needsUpdate(ctx, backend, path, password)
-		if err != nil {
-			logger.Error(err, "Failed to check if update needed", "path", path)
-			backendPaths = append(backendPaths, configv1alpha1.BackendPath{
-				Path:         path,
-				BMCName:      bmc.Name,
-				Region:       region,
-				Hostname:     hostname,
-				Username:     username,
-				LastSyncTime: syncTime,
-				SyncStatus:   "Failed",
-				ErrorMessage: err.Error(),
-			})
-			syncErrors++
-			continue
-		}
-
-		if !needsUpdate {
-			logger.V(1).Info("Secret already up to date", "path", path)
-			backendPaths = append(backendPaths, configv1alpha1.BackendPath{
-				Path:         path,
-				BMCName:      bmc.Name,
-				Region:       region,
-				Hostname:     hostname,
-				Username:     username,
-				LastSyncTime: syncTime,
-				SyncStatus:   "Success",
-			})
-			syncSuccess++
-			continue
-		}
+	location, err := r.BackendFactory.GetLocation(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get backend location")
+		*reconcileErr = err
+		return ctrl.Result{RequeueAfter: requeueAfterError}, err
+	}
 
-		// Write to backend
-		secretData := map[string]any{
-			"username": username,
-			"password": password,
-		}
+	groups := []syncGroup{{
+		location:    location,
+		backend:     backend,
+		pathBuilder: pathBuilder,
+		concurrency: maxConcurrentSyncs,
+	}}
+	req, err := r.newSyncRequest(ctx, bmcSecret, bmcs, groups, username, password)
+	if err != nil {
+		logger.Error(err, "Failed to prepare sync")
+		*reconcileErr = err
+		return ctrl.Result{RequeueAfter: requeueAfterError}, err
+	}
 
-		if err := backend.WriteSecret(ctx, path, secretData); err != nil {
-			logger.Error(err, "Failed to write secret to backend", "path", path)
-			r.Recorder.Eventf(bmcSecret, "Warning", "SyncFailed", "Failed to sync to %s: %v", path, err)
-			backendPaths = append(backendPaths, configv1alpha1.BackendPath{
-				Path:         path,
-				BMCName:      bmc.Name,
-				Region:       region,
-				Hostname:     hostname,
-				Username:     username,
-				LastSyncTime: syncTime,
-				SyncStatus:   "Failed",
-				ErrorMessage: err.Error(),
-			})
-			syncErrors++
-			continue
-		}
+	// Sync secrets for each BMC and track status
+	backendPaths := r.syncPaths(ctx, req, groups)
+	syncSuccess, syncErrors := countSyncResults(backendPaths)
+	syncTime := req.syncTime
 
-		logger.Info("Successfully synced secret", "path", path)
-		backendPaths = append(backendPaths, configv1alpha1.BackendPath{
-			Path:         path,
-			BMCName:      bmc.Name,
-			Region:       region,
-			Hostname:     hostname,
-			Username:     username,
-			LastSyncTime: syncTime,
-			SyncStatus:   "Success",
-		})
-		syncSuccess++
-	}
+	// Handle paths that were synced before but are no longer desired
+	orphanedPaths := r.pruneOrphans(ctx, bmcSecret, backendPaths)
 
 	// Update BMCSecretSyncStatus
-	if err := r.updateSyncStatus(ctx, bmcSecret.Name, backendPaths, len(bmcs), syncSuccess, syncErrors); err != nil {
+	if err := r.updateSyncStatus(ctx, bmcSecret.Name, backendPaths, orphanedPaths, len(backendPaths), syncSuccess, syncErrors); err != nil {
 		logger.Error(err, "Failed to update sync status")
 		// Don't fail reconciliation if status update fails
 	}
 
 	// Update status
 	if syncErrors > 0 {
-		r.Recorder.Eventf(bmcSecret, "Warning", "PartialSync", "Synced %d/%d secrets", syncSuccess, len(bmcs))
+		r.Recorder.Eventf(bmcSecret, "Warning", "PartialSync", "Synced %d/%d secrets", syncSuccess, len(backendPaths))
 	} else {
 		r.Recorder.Eventf(bmcSecret, "Normal", "Synced", "Successfully synced to %d backend paths", syncSuccess)
 	}
 
-	logger.Info("Reconciliation complete", "syncSuccess", syncSuccess, "syncErrors", syncErrors, "totalBMCs", len(bmcs))
+	logger.Info("Reconciliation complete", "syncSuccess", syncSuccess, "syncErrors", syncErrors, "totalPaths", len(backendPaths), "totalBMCs", len(bmcs))
 
 	if r.Metrics != nil {
 		r.Metrics.RecordSyncStatus(bmcSecret.Name, syncSuccess, syncErrors, syncTime.Time)
 	}
 
-	return ctrl.Result{RequeueAfter: requeueAfterNormal}, nil
+	return ctrl.Result{RequeueAfter: req.requeueAfter()}, nil
 }
 
 // reconcileMultiEngine handles reconciliation for multi-engine configuration
@@ -346,138 +510,64 @@ func (r *BMCSecretReconciler) reconcileMultiEngine(
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// Get region label key
-	regionLabelKey, err := r.BackendFactory.GetRegionLabelKey(ctx)
-	if err != nil {
-		logger.Error(err, "Failed to get region label key")
-		*reconcileErr = err
-		return ctrl.Result{RequeueAfter: requeueAfterError}, err
+	// Get matching engine backends based on BMCSecret labels. Engines whose backend
+	// cannot be created are reported on their own, the others are synced regardless.
+	engineBackends, enginesErr := r.BackendFactory.GetEngineBackends(ctx, bmcSecret.Labels)
+	engineErrs := secretbackend.EngineErrors(enginesErr)
+	if enginesErr != nil && len(engineErrs) == 0 {
+		logger.Error(enginesErr, "Failed to get engine backends")
+		r.Recorder.Event(bmcSecret, "Warning", "BackendUnavailable", enginesErr.Error())
+		*reconcileErr = enginesErr
+		return ctrl.Result{RequeueAfter: requeueAfterError}, enginesErr
 	}
-
-	// Get matching engine backends based on BMCSecret labels
-	engineBackends, err := r.BackendFactory.GetEngineBackends(ctx, bmcSecret.Labels)
-	if err != nil {
-		logger.Error(err, "Failed to get engine backends")
-		r.Recorder.Event(bmcSecret, "Warning", "BackendUnavailable", err.Error())
-		*reconcileErr = err
-		return ctrl.Result{RequeueAfter: requeueAfterError}, err
+	for _, engineErr := range engineErrs {
+		logger.Error(engineErr.Err, "Secret engine unavailable", "engine", engineErr.Engine)
+		r.Recorder.Event(bmcSecret, "Warning", "BackendUnavailable", engineErr.Error())
 	}
 
-	if len(engineBackends) == 0 {
+	if len(engineBackends) == 0 && len(engineErrs) == 0 {
 		logger.Info("No matching secret engines found for BMCSecret labels", "labels", bmcSecret.Labels)
 		r.Recorder.Event(bmcSecret, "Normal", "NoMatchingEngines", "No secret engines match this BMCSecret's labels")
+		r.pruneAllPaths(ctx, bmcSecret)
 		return ctrl.Result{RequeueAfter: requeueAfterNormal}, nil
 	}
 
 	logger.Info("Found matching secret engines", "count", len(engineBackends))
 
 	// Sync to all matching engines
-	syncErrors := 0
-	syncSuccess := 0
-	backendPaths := make([]configv1alpha1.BackendPath, 0, len(bmcs)*len(engineBackends))
-	syncTime := metav1.Now()
-
-	secretData := map[string]any{
-		"username": username,
-		"password": password,
-	}
-
+	groups := make([]syncGroup, 0, len(engineBackends))
 	for _, engineBackend := range engineBackends {
-		logger.Info("Syncing to engine", "engine", engineBackend.EngineName)
-
-		for _, bmc := range bmcs {
-			region := bmcresolver.ExtractRegionFromBMC(&bmc, regionLabelKey)
-			hostname := bmcresolver.GetHostnameFromBMC(&bmc)
-
-			// Build path using engine's path builder
-			path, err := engineBackend.PathBuilder.Build(secretbackend.PathVariables{
-				Region:   region,
-				Hostname: hostname,
-				Username: username,
-			})
-			if err != nil {
-				logger.Error(err, "Failed to build path", "bmc", bmc.Name, "engine", engineBackend.EngineName)
-				backendPaths = append(backendPaths, configv1alpha1.BackendPath{
-					Path:         path,
-					BMCName:      bmc.Name,
-					Region:       region,
-					Hostname:     hostname,
-					Username:     username,
-					LastSyncTime: syncTime,
-					SyncStatus:   "Failed",
-					ErrorMessage: fmt.Sprintf("[%s] %s", engineBackend.EngineName, err.Error()),
-				})
-				syncErrors++
-				continue
-			}
-
-			// Check if update needed
-			needsUpdate, err := r.needsUpdate(ctx, engineBackend.Backend, path, password)
-			if err != nil {
-				logger.Error(err, "Failed to check if update needed", "path", path, "engine", engineBackend.EngineName)
-				backendPaths = append(backendPaths, configv1alpha1.BackendPath{
-					Path:         path,
-					BMCName:      bmc.Name,
-					Region:       region,
-					Hostname:     hostname,
-					Username:     username,
-					LastSyncTime: syncTime,
-					SyncStatus:   "Failed",
-					ErrorMessage: fmt.Sprintf("[%s] %s", engineBackend.EngineName, err.Error()),
-				})
-				syncErrors++
-				continue
-			}
-
-			if !needsUpdate {
-				logger.V(1).Info("Secret already up to date", "path", path, "engine", engineBackend.EngineName)
-				backendPaths = append(backendPaths, configv1alpha1.BackendPath{
-					Path:         path,
-					BMCName:      bmc.Name,
-					Region:       region,
-					Hostname:     hostname,
-					Username:     username,
-					LastSyncTime: syncTime,
-					SyncStatus:   "Success",
-				})
-				syncSuccess++
-				continue
-			}
+		groups = append(groups, syncGroup{
+			engine:      engineBackend.EngineName,
+			location:    engineBackend.Location,
+			backend:     engineBackend.Backend,
+			pathBuilder: engineBackend.PathBuilder,
+			concurrency: engineBackend.MaxConcurrentSyncs,
+		})
+	}
 
-			// Write to backend
-			if err := engineBackend.Backend.WriteSecret(ctx, path, secretData); err != nil {
-				logger.Error(err, "Failed to write secret to backend", "path", path, "engine", engineBackend.EngineName)
-				r.Recorder.Eventf(bmcSecret, "Warning", "SyncFailed", "Failed to sync to %s (engine %s): %v", path, engineBackend.EngineName, err)
-				backendPaths = append(backendPaths, configv1alpha1.BackendPath{
-					Path:         path,
-					BMCName:      bmc.Name,
-					Region:       region,
-					Hostname:     hostname,
-					Username:     username,
-					LastSyncTime: syncTime,
-					SyncStatus:   "Failed",
-					ErrorMessage: fmt.Sprintf("[%s] %s", engineBackend.EngineName, err.Error()),
-				})
-				syncErrors++
-				continue
-			}
+	req, err := r.newSyncRequest(ctx, bmcSecret, bmcs, groups, username, password)
+	if err != nil {
+		logger.Error(err, "Failed to prepare sync")
+		*reconcileErr = err
+		return ctrl.Result{RequeueAfter: requeueAfterError}, err
+	}
 
-			logger.Info("Successfully synced secret", "path", path, "engine", engineBackend.EngineName)
-			backendPaths = append(backendPaths, configv1alpha1.BackendPath{
-				Path:         path,
-				BMCName:      bmc.Name,
-				Region:       region,
-				Hostname:     hostname,
-				Username:     username,
-				LastSyncTime: syncTime,
-				SyncStatus:   "Success",
-			})
-			syncSuccess++
-		}
+	backendPaths := r.syncPaths(ctx, req, groups)
+	// Paths synced to unavailable engines are still desired and must not be pruned
+	for _, engineErr := range engineErrs {
+		backendPaths = append(backendPaths, req.keepPreviousPaths(func(backendPath configv1alpha1.BackendPath) bool {
+			return backendPath.Engine == engineErr.Engine
+		}, engineErr)...)
 	}
+	syncSuccess, syncErrors := countSyncResults(backendPaths)
+	syncTime := req.syncTime
+
+	// Handle paths that were synced before but are no longer desired
+	orphanedPaths := r.pruneOrphans(ctx, bmcSecret, backendPaths)
 
 	// Update BMCSecretSyncStatus
-	if err := r.updateSyncStatus(ctx, bmcSecret.Name, backendPaths, len(backendPaths), syncSuccess, syncErrors); err != nil {
+	if err := r.updateSyncStatus(ctx, bmcSecret.Name, backendPaths, orphanedPaths, len(backendPaths), syncSuccess, syncErrors); err != nil {
 		logger.Error(err, "Failed to update sync status")
 		// Don't fail reconciliation if status update fails
 	}
@@ -495,208 +585,1277 @@ func (r *BMCSecretReconciler) reconcileMultiEngine(
 		r.Metrics.RecordSyncStatus(bmcSecret.Name, syncSuccess, syncErrors, syncTime.Time)
 	}
 
-	return ctrl.Result{RequeueAfter: requeueAfterNormal}, nil
-}
-
-// handleDeletion handles cleanup when BMCSecret is being deleted
-func (r *BMCSecretReconciler) handleDeletion(ctx context.Context, bmcSecret *metalv1alpha1.BMCSecret) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
-
-	start := time.Now()
-	defer func() {
-		if r.Metrics != nil {
-			r.Metrics.RecordReconcileDuration("deletion", time.Since(start))
-		}
-	}()
-
-	if !controllerutil.ContainsFinalizer(bmcSecret, bmcSecretFinalizer) {
-		return ctrl.Result{}, nil
+	if len(engineErrs) > 0 {
+		*reconcileErr = enginesErr
+		return ctrl.Result{RequeueAfter: min(req.requeueAfter(), requeueAfterError)}, nil
 	}
+	return ctrl.Result{RequeueAfter: req.requeueAfter()}, nil
+}
 
-	logger.Info("Cleaning up backend secrets")
+// syncRequest holds what is needed to sync the credentials of a BMCSecret
+type syncRequest struct {
+	bmcSecret      *metalv1alpha1.BMCSecret
+	bmcs           []metalv1alpha1.BMC
+	regionLabelKey string
+	data           map[string]any
+	syncTime       metav1.Time
+
+	// previous holds the paths recorded in the BMCSecretSyncStatus by backendPathKey
+	previous map[string]configv1alpha1.BackendPath
+	// verificationInterval is how long a recorded content hash is trusted
+	// before the secret is read back again
+	verificationInterval time.Duration
+	// hashKey is the key of the recorded content hashes
+	hashKey []byte
+
+	// owner is recorded in the ownership marker of every written secret
+	owner secretbackend.Owner
+	// adoptPolicy controls existing secrets without an ownership marker
+	adoptPolicy string
+
+	// writeConflict is set when a check-and-set write lost against a concurrent
+	// writer, so the BMCSecret is reconciled again soon
+	writeConflict atomic.Bool
+
+	// collisions holds the reason paths are not synced because of colliding
+	// with other paths, by backendPathKey
+	collisions map[string]error
+}
 
-	// Delete corresponding BMCSecretSyncStatus
-	syncStatus := &configv1alpha1.BMCSecretSyncStatus{}
-	syncStatusName := fmt.Sprintf("%s-sync-status", bmcSecret.Name)
-	if err := r.Get(ctx, types.NamespacedName{Name: syncStatusName}, syncStatus); err == nil {
-		if err := r.Delete(ctx, syncStatus); err != nil && !errors.IsNotFound(err) {
-			logger.Error(err, "Failed to delete BMCSecretSyncStatus")
-			// Continue with cleanup even if status deletion fails
+// keepPreviousPaths returns the paths recorded by the previous sync that match keep,
+// marked failed with err. Paths that cannot be synced right now are carried over, so
+// they are neither pruned as orphans nor dropped from the BMCSecretSyncStatus.
+func (req *syncRequest) keepPreviousPaths(keep func(configv1alpha1.BackendPath) bool, err error) []configv1alpha1.BackendPath {
+	var kept []configv1alpha1.BackendPath
+	for _, backendPath := range req.previous {
+		if backendPath.SyncStatus == "Conflict" || !keep(backendPath) {
+			continue
 		}
+		backendPath.SyncStatus = "Failed"
+		backendPath.ErrorMessage = err.Error()
+		kept = append(kept, backendPath)
 	}
+	slices.SortFunc(kept, func(a, b configv1alpha1.BackendPath) int {
+		return strings.Compare(backendPathKey(a), backendPathKey(b))
+	})
+	return kept
+}
 
-	// Get backend
-	backend, err := r.BackendFactory.GetBackend(ctx)
-	if err != nil {
-		logger.Error(err, "Failed to get backend during cleanup, allowing deletion to proceed")
-		r.Recorder.Event(bmcSecret, "Warning", "CleanupFailed", "Backend unavailable during cleanup")
-		// Allow deletion to proceed even if backend is unavailable
-		controllerutil.RemoveFinalizer(bmcSecret, bmcSecretFinalizer)
-		if err := r.Update(ctx, bmcSecret); err != nil {
-			return ctrl.Result{}, err
-		}
-		return ctrl.Result{}, nil
+// requeueAfter returns when the BMCSecret is reconciled again after the sync
+func (req *syncRequest) requeueAfter() time.Duration {
+	if req.writeConflict.Load() {
+		return requeueAfterConflict
 	}
+	return requeueAfterNormal
+}
 
-	// Get path builder and region label key
-	pathBuilder, err := r.BackendFactory.GetPathBuilder(ctx)
+// newSyncRequest gathers the configuration and previous sync results for syncing a BMCSecret
+// to the groups. BMCs are sorted by name so results are recorded in a stable order.
+func (r *BMCSecretReconciler) newSyncRequest(
+	ctx context.Context,
+	bmcSecret *metalv1alpha1.BMCSecret,
+	bmcs []metalv1alpha1.BMC,
+	groups []syncGroup,
+	username, password string,
+) (*syncRequest, error) {
+	regionLabelKey, err := r.BackendFactory.GetRegionLabelKey(ctx)
 	if err != nil {
-		logger.Error(err, "Failed to get path builder during cleanup")
-		// Continue with deletion
-		controllerutil.RemoveFinalizer(bmcSecret, bmcSecretFinalizer)
-		if err := r.Update(ctx, bmcSecret); err != nil {
-			return ctrl.Result{}, err
-		}
-		return ctrl.Result{}, nil
+		return nil, fmt.Errorf("failed to get region label key: %w", err)
 	}
 
-	regionLabelKey, err := r.BackendFactory.GetRegionLabelKey(ctx)
+	verificationInterval, err := r.BackendFactory.GetVerificationInterval(ctx)
 	if err != nil {
-		logger.Error(err, "Failed to get region label key during cleanup")
-		// Continue with deletion
-		controllerutil.RemoveFinalizer(bmcSecret, bmcSecretFinalizer)
-		if err := r.Update(ctx, bmcSecret); err != nil {
-			return ctrl.Result{}, err
-		}
-		return ctrl.Result{}, nil
+		return nil, fmt.Errorf("failed to get verification interval: %w", err)
 	}
 
-	// Extract credentials
-	username, _, err := bmcresolver.ExtractCredentials(bmcSecret)
+	hashKey, err := r.BackendFactory.GetContentHashKey(ctx)
 	if err != nil {
-		logger.Error(err, "Failed to extract credentials during cleanup, proceeding with deletion")
-		controllerutil.RemoveFinalizer(bmcSecret, bmcSecretFinalizer)
-		if err := r.Update(ctx, bmcSecret); err != nil {
-			return ctrl.Result{}, err
-		}
-		return ctrl.Result{}, nil
+		return nil, fmt.Errorf("failed to get content hash key: %w", err)
 	}
 
-	// Find associated BMCs
-	bmcs, err := bmcresolver.FindBMCsForSecret(ctx, r.Client, bmcSecret.Name)
+	owner, adoptPolicy, err := r.secretOwner(ctx, bmcSecret.Name)
 	if err != nil {
-		logger.Error(err, "Failed to find BMCs during cleanup")
-		// Continue with deletion
-		controllerutil.RemoveFinalizer(bmcSecret, bmcSecretFinalizer)
-		if err := r.Update(ctx, bmcSecret); err != nil {
-			return ctrl.Result{}, err
-		}
-		return ctrl.Result{}, nil
+		return nil, err
 	}
 
-	// Delete secrets from backend
-	for _, bmc := range bmcs {
-		region := bmcresolver.ExtractRegionFromBMC(&bmc, regionLabelKey)
-		hostname := bmcresolver.GetHostnameFromBMC(&bmc)
-
-		path, err := pathBuilder.Build(secretbackend.PathVariables{
-			Region:   region,
-			Hostname: hostname,
-			Username: username,
-		})
-		if err != nil {
-			logger.Error(err, "Failed to build path during cleanup", "bmc", bmc.Name)
-			continue
-		}
-
-		if err := backend.DeleteSecret(ctx, path); err != nil {
-			logger.Error(err, "Failed to delete secret from backend", "path", path)
-			// Continue with other deletions
-			continue
-		}
+	sortedBMCs := slices.Clone(bmcs)
+	slices.SortFunc(sortedBMCs, func(a, b metalv1alpha1.BMC) int {
+		return strings.Compare(a.Name, b.Name)
+	})
 
-		logger.Info("Deleted secret from backend", "path", path)
+	locations := make(map[string]secretbackend.Location, len(groups))
+	for _, group := range groups {
+		locations[group.engine] = group.location
 	}
-
-	// Remove finalizer
-	controllerutil.RemoveFinalizer(bmcSecret, bmcSecretFinalizer)
-	if err := r.Update(ctx, bmcSecret); err != nil {
-		return ctrl.Result{}, err
+	previous := make(map[string]configv1alpha1.BackendPath)
+	if syncStatus, err := r.getSyncStatus(ctx, bmcSecret.Name); err == nil {
+		for _, backendPath := range locateBackendPaths(syncStatus.Status.BackendPaths, locations) {
+			previous[backendPathKey(backendPath)] = backendPath
+		}
+	} else if !errors.IsNotFound(err) {
+		// Without previous results every path is verified against the backend
+		log.FromContext(ctx).Error(err, "Failed to get BMCSecretSyncStatus, verifying all paths")
 	}
 
-	return ctrl.Result{}, nil
+	return &syncRequest{
+		bmcSecret:      bmcSecret,
+		bmcs:           sortedBMCs,
+		regionLabelKey: regionLabelKey,
+		data: map[string]any{
+			"username": username,
+			"password": password,
+		},
+		syncTime:             metav1.Now(),
+		previous:             previous,
+		verificationInterval: verificationInterval,
+		hashKey:              hashKey,
+		owner:                owner,
+		adoptPolicy:          adoptPolicy,
+	}, nil
 }
 
-// needsUpdate checks if the secret needs to be updated in the backend
-func (r *BMCSecretReconciler) needsUpdate(ctx context.Context, backend secretbackend.Backend, path, password string) (bool, error) {
-	// Check if secret exists
-	exists, err := backend.SecretExists(ctx, path)
+// secretOwner returns the owner recorded in the ownership marker of the secrets
+// synced for a BMCSecret, together with the configured adopt policy
+func (r *BMCSecretReconciler) secretOwner(ctx context.Context, bmcSecretName string) (secretbackend.Owner, string, error) {
+	clusterID, err := r.BackendFactory.GetClusterID(ctx)
 	if err != nil {
-		return false, err
+		return secretbackend.Owner{}, "", fmt.Errorf("failed to get cluster ID: %w", err)
 	}
 
-	if !exists {
-		return true, nil
+	configName, err := r.BackendFactory.GetConfigName(ctx)
+	if err != nil {
+		return secretbackend.Owner{}, "", fmt.Errorf("failed to get config name: %w", err)
 	}
 
-	// Read current secret
-	currentData, err := backend.ReadSecret(ctx, path)
+	adoptPolicy, err := r.BackendFactory.GetAdoptPolicy(ctx)
 	if err != nil {
-		return false, err
+		return secretbackend.Owner{}, "", fmt.Errorf("failed to get adopt policy: %w", err)
 	}
 
-	// Compare passwords
-	currentPassword, ok := currentData["password"].(string)
-	if !ok {
-		return true, nil
-	}
+	return secretbackend.Owner{
+		ClusterID: clusterID,
+		BMCSecret: bmcSecretName,
+		Config:    configName,
+	}, adoptPolicy, nil
+}
 
-	return currentPassword != password, nil
+// syncGroup is a backend that all BMC paths of a BMCSecret are synced to
+type syncGroup struct {
+	// engine is the secret engine name, empty for the single-engine configuration
+	engine string
+	// location is where the backend stores secrets
+	location    secretbackend.Location
+	backend     secretbackend.Backend
+	pathBuilder *secretbackend.PathBuilder
+	// concurrency is the number of paths synced in parallel to this backend
+	concurrency int
 }
 
-// SetupWithManager sets up the controller with the Manager
-func (r *BMCSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// Get sync label configuration to create predicate
-	syncLabel, err := r.BackendFactory.GetSyncLabel(context.Background())
-	if err != nil {
-		return fmt.Errorf("failed to get sync label configuration: %w", err)
+// backendPath returns the backend path at path in the location of the group
+func (g *syncGroup) backendPath(path string) configv1alpha1.BackendPath {
+	return configv1alpha1.BackendPath{
+		Path:      path,
+		Engine:    g.engine,
+		Address:   g.location.Address,
+		Namespace: g.location.Namespace,
+		MountPath: g.location.MountPath,
 	}
+}
 
-	// Create predicate for label filtering
-	var labelPredicate predicate.Predicate
-	if syncLabel != "" {
-		labelPredicate = predicate.NewPredicateFuncs(func(object client.Object) bool {
-			if object.GetLabels() == nil {
-				return false
-			}
-			_, exists := object.GetLabels()[syncLabel]
-			return exists
-		})
+// syncPaths syncs the credentials of every BMC to every group. Each group has its
+// own pool of workers, so a slow engine does not hold back the others. The result
+// holds one entry per group and BMC, ordered by group and then by BMC name.
+func (r *BMCSecretReconciler) syncPaths(ctx context.Context, req *syncRequest, groups []syncGroup) []configv1alpha1.BackendPath {
+	req.collisions = r.pathCollisions(ctx, req, groups)
+	results := make([]configv1alpha1.BackendPath, len(groups)*len(req.bmcs))
+
+	var wg sync.WaitGroup
+	for groupIdx, group := range groups {
+		queue := make(chan int, len(req.bmcs))
+		for bmcIdx := range req.bmcs {
+			queue <- bmcIdx
+		}
+		close(queue)
+
+		workers := min(max(group.concurrency, 1), len(req.bmcs))
+		for range workers {
+			wg.Go(func() {
+				for bmcIdx := range queue {
+					results[groupIdx*len(req.bmcs)+bmcIdx] = r.syncPath(ctx, req, group, &req.bmcs[bmcIdx])
+				}
+			})
+		}
 	}
-
-	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&metalv1alpha1.BMCSecret{})
-
-	// Apply label predicate if sync label is configured
-	if labelPredicate != nil {
-		builder = builder.WithEventFilter(labelPredicate)
+	wg.Wait()
+
+	// The paths previously synced for a BMC whose path cannot be built anymore are
+	// still desired, so they are carried over instead of being pruned as orphans
+	backendPaths := make([]configv1alpha1.BackendPath, 0, len(results))
+	for _, result := range results {
+		if result.Path == "" {
+			kept := req.keepPreviousPaths(func(backendPath configv1alpha1.BackendPath) bool {
+				return backendPath.BMCName == result.BMCName && backendPath.Engine == result.Engine
+			}, goerrors.New(result.ErrorMessage))
+			if len(kept) > 0 {
+				backendPaths = append(backendPaths, kept...)
+				continue
+			}
+		}
+		backendPaths = append(backendPaths, result)
 	}
 
-	return builder.
-		Watches(
-			&metalv1alpha1.BMC{},
-			handler.EnqueueRequestsFromMapFunc(r.findBMCSecretsForBMC),
-		).
-		Complete(r)
+	return backendPaths
+}
+
+// pathCollisions finds the paths that are not synced because distinct BMCs render the
+// same path in a group, or because the path is already synced for another BMCSecret
+// with the same configuration and engine. Colliding paths are reported as failed
+// instead of letting the last writer win.
+func (r *BMCSecretReconciler) pathCollisions(ctx context.Context, req *syncRequest, groups []syncGroup) map[string]error {
+	logger := log.FromContext(ctx)
+	username, _ := req.data["username"].(string)
+
+	// BMCs by the path they render, in the order of first appearance
+	var backendPaths []configv1alpha1.BackendPath
+	bmcNames := make(map[string][]string)
+	for _, group := range groups {
+		for i := range req.bmcs {
+			bmc := &req.bmcs[i]
+			region := bmcresolver.ExtractRegionFromBMC(bmc, req.regionLabelKey)
+			hostname := bmcresolver.GetHostnameFromBMC(bmc)
+			path, err := group.pathBuilder.Build(pathVariables(bmc, req.bmcSecret.Name, region, hostname, username))
+			if err != nil {
+				// Reported by syncPath
+				continue
+			}
+			backendPath := group.backendPath(path)
+			key := backendPathKey(backendPath)
+			if _, ok := bmcNames[key]; !ok {
+				backendPaths = append(backendPaths, backendPath)
+			}
+			bmcNames[key] = append(bmcNames[key], bmc.Name)
+		}
+	}
+
+	collisions := make(map[string]error)
+	for _, backendPath := range backendPaths {
+		key := backendPathKey(backendPath)
+		if names := bmcNames[key]; len(names) > 1 {
+			collisions[key] = fmt.Errorf("path collision: BMCs %s render the same path", strings.Join(names, ", "))
+			continue
+		}
+
+		var claims configv1alpha1.BMCSecretSyncStatusList
+		if err := r.List(ctx, &claims, client.MatchingFields{backendPathField: r.configName + "/" + key}); err != nil {
+			// Ownership markers still keep the path from being overwritten
+			logger.Error(err, "Failed to look up BMCSecrets syncing the same path", "path", backendPath.Path, "engine", backendPath.Engine)
+			continue
+		}
+		for _, claim := range claims.Items {
+			if claim.Spec.BMCSecretRef != req.bmcSecret.Name {
+				collisions[key] = fmt.Errorf("path collision: the path is already synced for BMCSecret %s", claim.Spec.BMCSecretRef)
+				break
+			}
+		}
+	}
+
+	return collisions
+}
+
+// syncPath writes the credentials of a single BMC to the group's backend if they changed.
+// While the content hash recorded for the path matches and its verification is not due,
+// the backend is not read at all.
+func (r *BMCSecretReconciler) syncPath(
+	ctx context.Context,
+	req *syncRequest,
+	group syncGroup,
+	bmc *metalv1alpha1.BMC,
+) configv1alpha1.BackendPath {
+	logger := log.FromContext(ctx).WithValues("bmc", bmc.Name)
+	if group.engine != "" {
+		logger = logger.WithValues("engine", group.engine)
+	}
+
+	region := bmcresolver.ExtractRegionFromBMC(bmc, req.regionLabelKey)
+	hostname := bmcresolver.GetHostnameFromBMC(bmc)
+	username, _ := req.data["username"].(string)
+
+	result := group.backendPath("")
+	result.BMCName = bmc.Name
+	result.Region = region
+	result.Hostname = hostname
+	result.Username = username
+	result.LastSyncTime = req.syncTime
+	result.SyncStatus = "Success"
+
+	failed := func(err error) configv1alpha1.BackendPath {
+		result.SyncStatus = "Failed"
+		result.ErrorMessage = err.Error()
+		if group.engine != "" {
+			result.ErrorMessage = fmt.Sprintf("[%s] %s", group.engine, err.Error())
+		}
+		return result
+	}
+
+	// Build path
+	// Without a path the result is replaced by the paths previously synced for the BMC
+	path, err := group.pathBuilder.Build(pathVariables(bmc, req.bmcSecret.Name, region, hostname, username))
+	if err != nil {
+		logger.Error(err, "Failed to build path")
+		return failed(err)
+	}
+	result.Path = path
+
+	if err := req.collisions[backendPathKey(result)]; err != nil {
+		logger.Info("Not syncing colliding backend path", "path", path, "reason", err.Error())
+		r.Recorder.Eventf(req.bmcSecret, "Warning", "PathCollision", "Backend path %s was not synced: %v", describeBackendPath(result), err)
+		return failed(err)
+	}
+
+	// Skip the backend while the recorded hash matches and no verification is due
+	previous, hasPrevious := req.previous[backendPathKey(result)]
+	if hasPrevious && previous.SyncStatus == "Success" && previous.LastVerifiedTime != nil &&
+		req.syncTime.Sub(previous.LastVerifiedTime.Time) < req.verificationInterval &&
+		secretbackend.ContentHashMatches(req.hashKey, previous.ContentHash, req.data) {
+		logger.V(1).Info("Secret unchanged since last verification", "path", path)
+		result.ContentHash = previous.ContentHash
+		result.LastVerifiedTime = previous.LastVerifiedTime
+		result.Version = previous.Version
+		result.VersionCreatedTime = previous.VersionCreatedTime
+		return result
+	}
+
+	// Secrets without a marker at paths this BMCSecret synced successfully
+	// were written before ownership markers were introduced
+	adoptPolicy := req.adoptPolicy
+	if hasPrevious && previous.SyncStatus == "Success" {
+		adoptPolicy = secretbackend.AdoptPolicyAdopt
+	}
+
+	conflict := func(err error) configv1alpha1.BackendPath {
+		logger.Info("Refusing to overwrite secret not owned by this BMCSecret", "path", path, "reason", err.Error())
+		r.Recorder.Eventf(req.bmcSecret, "Warning", "OwnershipConflict", "Backend path %s was left untouched: %v", describeBackendPath(result), err)
+		result = failed(err)
+		result.SyncStatus = "Conflict"
+		return result
+	}
+
+	// Check if update needed
+	needsUpdate, current, err := r.needsUpdate(ctx, group.backend, path, req.data, req.owner, adoptPolicy)
+	if goerrors.Is(err, secretbackend.ErrOwnershipConflict) {
+		return conflict(err)
+	}
+	if err != nil {
+		logger.Error(err, "Failed to check if update needed", "path", path)
+		return failed(err)
+	}
+
+	var version secretbackend.SecretVersion
+	if current != nil {
+		version = current.SecretVersion
+	}
+
+	if needsUpdate {
+		// Write to backend, unless the secret changed since it was read
+		opts := secretbackend.WriteOptions{Metadata: req.owner.Metadata()}
+		if current != nil {
+			opts.CAS = &current.Version
+		}
+		written, err := group.backend.WriteSecret(ctx, path, req.data, opts)
+		version = written
+		if goerrors.Is(err, secretbackend.ErrCASMismatch) {
+			// Someone else wrote the secret in the meantime. Look at what they wrote
+			// instead of overwriting it blindly, and try again on the next reconcile.
+			logger.Info("Secret changed concurrently, reading it again", "path", path)
+			stillNeedsUpdate, reread, readErr := r.needsUpdate(ctx, group.backend, path, req.data, req.owner, adoptPolicy)
+			switch {
+			case goerrors.Is(readErr, secretbackend.ErrOwnershipConflict):
+				return conflict(readErr)
+			case readErr == nil && !stillNeedsUpdate:
+				version = reread.SecretVersion
+				err = nil
+			default:
+				req.writeConflict.Store(true)
+			}
+		}
+		if err != nil {
+			logger.Error(err, "Failed to write secret to backend", "path", path)
+			if group.engine != "" {
+				r.Recorder.Eventf(req.bmcSecret, "Warning", "SyncFailed", "Failed to sync to %s (engine %s): %v", path, group.engine, err)
+			} else {
+				r.Recorder.Eventf(req.bmcSecret, "Warning", "SyncFailed", "Failed to sync to %s: %v", path, err)
+			}
+			return failed(err)
+		}
+		logger.Info("Successfully synced secret", "path", path)
+	} else {
+		logger.V(1).Info("Secret already up to date", "path", path)
+	}
+
+	// The backend now holds exactly the desired data
+	verifiedTime := req.syncTime
+	result.LastVerifiedTime = &verifiedTime
+	result.Version = version.Version
+	if !version.CreatedTime.IsZero() {
+		result.VersionCreatedTime = &metav1.Time{Time: version.CreatedTime}
+	}
+	if hasPrevious && secretbackend.ContentHashMatches(req.hashKey, previous.ContentHash, req.data) {
+		result.ContentHash = previous.ContentHash
+	} else if result.ContentHash, err = secretbackend.ContentHash(req.hashKey, req.data); err != nil {
+		// Without a hash the path is simply verified again next time
+		logger.Error(err, "Failed to hash secret data", "path", path)
+	}
+
+	return result
+}
+
+// countSyncResults returns the number of successful and failed backend paths
+func countSyncResults(backendPaths []configv1alpha1.BackendPath) (success, failed int) {
+	for _, backendPath := range backendPaths {
+		if backendPath.SyncStatus == "Success" {
+			success++
+		} else {
+			failed++
+		}
+	}
+	return success, failed
+}
+
+// handleDeletion handles cleanup when BMCSecret is being deleted
+func (r *BMCSecretReconciler) handleDeletion(
+	ctx context.Context,
+	bmcSecret *metalv1alpha1.BMCSecret,
+	configs []*BMCSecretReconciler,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	start := time.Now()
+	defer func() {
+		if r.Metrics != nil {
+			r.Metrics.RecordReconcileDuration("deletion", time.Since(start))
+		}
+	}()
+
+	if !controllerutil.ContainsFinalizer(bmcSecret, bmcSecretFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Cleaning up backend secrets")
+
+	for _, config := range configs {
+		config.cleanupBackend(config.logContext(ctx), bmcSecret)
+	}
+
+	if err := r.removeFinalizer(ctx, bmcSecret); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// cleanupBackend deletes the secrets synced for a deleted BMCSecret with the
+// configuration of the reconciler, together with its BMCSecretSyncStatus
+func (r *BMCSecretReconciler) cleanupBackend(ctx context.Context, bmcSecret *metalv1alpha1.BMCSecret) {
+	logger := log.FromContext(ctx)
+
+	// Prefer the paths recorded in the BMCSecretSyncStatus: they are exactly
+	// what was written, even if BMCs, labels or templates changed since
+	syncStatus, err := r.getSyncStatus(ctx, bmcSecret.Name)
+	syncStatusFound := err == nil
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to get BMCSecretSyncStatus during cleanup")
+	}
+
+	var recordedPaths []configv1alpha1.BackendPath
+	if syncStatusFound {
+		recordedPaths = syncStatus.Status.BackendPaths
+		// Orphans left behind by a failed prune are still owned by this BMCSecret
+		if policy, err := r.BackendFactory.GetOrphanPolicy(ctx); err == nil && policy == secretbackend.OrphanPolicyDelete {
+			recordedPaths = slices.Concat(recordedPaths, syncStatus.Status.OrphanedPaths)
+		}
+	}
+
+	var targets []deletionTarget
+	if len(recordedPaths) > 0 {
+		var closeBackends func()
+		targets, closeBackends = r.recordedDeletionTargets(ctx, bmcSecret, recordedPaths)
+		defer closeBackends()
+	} else if r.inScope(ctx, bmcSecret) {
+		targets = r.computedDeletionTargets(ctx, bmcSecret)
+	}
+
+	owner, adoptPolicy, err := r.secretOwner(ctx, bmcSecret.Name)
+	if err != nil {
+		logger.Error(err, "Failed to get ownership configuration during cleanup, allowing deletion to proceed")
+		r.Recorder.Event(bmcSecret, "Warning", "CleanupFailed", "Ownership configuration unavailable during cleanup")
+		targets = nil
+	}
+
+	// Delete secrets from backend
+	for _, target := range targets {
+		if target.policy == secretbackend.DeletionPolicyRetain {
+			logger.Info("Retaining secret in backend per deletion policy", "path", target.path, "engine", target.engine)
+			continue
+		}
+		if err := r.deleteOwnedSecret(ctx, target, owner, adoptPolicy); err != nil {
+			if goerrors.Is(err, secretbackend.ErrOwnershipConflict) {
+				logger.Info("Not deleting secret not owned by this BMCSecret", "path", target.path, "engine", target.engine, "reason", err.Error())
+				r.Recorder.Eventf(bmcSecret, "Warning", "OwnershipConflict", "Backend path %s was not deleted: %v",
+					describeBackendPath(target.backendPath()), err)
+				continue
+			}
+			logger.Error(err, "Failed to delete secret from backend", "path", target.path, "engine", target.engine)
+			// Continue with other deletions
+			continue
+		}
+
+		logger.Info("Deleted secret from backend", "path", target.path, "engine", target.engine, "policy", target.policy)
+	}
+
+	// Delete corresponding BMCSecretSyncStatus
+	if syncStatusFound {
+		if err := r.Delete(ctx, syncStatus); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to delete BMCSecretSyncStatus")
+			// Continue with cleanup even if status deletion fails
+		}
+	}
+}
+
+// inScope reports whether the BMCSecret matches the sync selector of the configuration.
+// It also reports true if the selector is unavailable, so cleanup errs on the safe side.
+func (r *BMCSecretReconciler) inScope(ctx context.Context, bmcSecret *metalv1alpha1.BMCSecret) bool {
+	syncSelector, err := r.BackendFactory.GetSyncSelector(ctx)
+	if err != nil {
+		return true
+	}
+	return syncSelector.Matches(labels.Set(bmcSecret.Labels))
+}
+
+// removeFinalizer removes the cleanup finalizer from the BMCSecret if present
+func (r *BMCSecretReconciler) removeFinalizer(ctx context.Context, bmcSecret *metalv1alpha1.BMCSecret) error {
+	if !controllerutil.ContainsFinalizer(bmcSecret, bmcSecretFinalizer) {
+		return nil
+	}
+
+	controllerutil.RemoveFinalizer(bmcSecret, bmcSecretFinalizer)
+	if err := r.Update(ctx, bmcSecret); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to remove finalizer")
+		return err
+	}
+	return nil
+}
+
+// deletionTarget is a backend path to delete during cleanup
+type deletionTarget struct {
+	backend secretbackend.Backend
+	engine  string
+	path    string
+	// location is where the path was recorded, empty if it was computed or recorded
+	// before locations were
+	location secretbackend.Location
+	// policy is the deletion policy of the engine the path belongs to
+	policy string
+	// synced is set for paths recorded as successfully synced by the BMCSecret,
+	// which are deleted even without an ownership marker
+	synced bool
+}
+
+// backendPath returns the backend path the target deletes
+func (t *deletionTarget) backendPath() configv1alpha1.BackendPath {
+	return configv1alpha1.BackendPath{
+		Path:      t.path,
+		Engine:    t.engine,
+		Address:   t.location.Address,
+		Namespace: t.location.Namespace,
+		MountPath: t.location.MountPath,
+	}
+}
+
+// recordedDeletionTargets resolves the backend paths recorded in the sync status
+// to the backends they were written to. Paths recorded at a location the engine
+// has moved away from are deleted through backends created for that location,
+// which are closed by the returned function.
+func (r *BMCSecretReconciler) recordedDeletionTargets(
+	ctx context.Context,
+	bmcSecret *metalv1alpha1.BMCSecret,
+	backendPaths []configv1alpha1.BackendPath,
+) ([]deletionTarget, func()) {
+	logger := log.FromContext(ctx)
+
+	var (
+		defaultBackend  secretbackend.Backend
+		defaultLocation secretbackend.Location
+		defaultPolicy   string
+		engineBackends  map[string]*secretbackend.EngineBackend
+		targets         []deletionTarget
+	)
+	unavailable := make(map[string]bool)
+	seen := make(map[string]bool, len(backendPaths))
+
+	// Backends at previous locations by engine and location
+	type engineLocation struct {
+		engine   string
+		location secretbackend.Location
+	}
+	locationBackends := make(map[engineLocation]secretbackend.Backend)
+	closeBackends := func() {
+		for _, backend := range locationBackends {
+			if err := backend.Close(); err != nil {
+				logger.Error(err, "Failed to close backend at previous location")
+			}
+		}
+	}
+	// backendAt returns the backend at the location the path was recorded at
+	backendAt := func(backendPath configv1alpha1.BackendPath, current secretbackend.Backend, currentLocation secretbackend.Location) (secretbackend.Backend, error) {
+		location := pathLocation(backendPath)
+		if backendPath.MountPath == "" || location == currentLocation {
+			return current, nil
+		}
+		key := engineLocation{engine: backendPath.Engine, location: location}
+		if backend, ok := locationBackends[key]; ok {
+			return backend, nil
+		}
+		backend, err := r.BackendFactory.NewLocationBackend(ctx, backendPath.Engine, location)
+		if err != nil {
+			return nil, err
+		}
+		locationBackends[key] = backend
+		return backend, nil
+	}
+
+	for _, backendPath := range backendPaths {
+		// Paths that failed to build were never written, conflicting ones never touched
+		if backendPath.Path == "" || backendPath.SyncStatus == "Conflict" {
+			continue
+		}
+		key := backendPathKey(backendPath)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if backendPath.Engine == "" {
+			if defaultBackend == nil {
+				backend, policy, err := r.defaultBackend(ctx)
+				if err == nil {
+					defaultLocation, err = r.BackendFactory.GetLocation(ctx)
+				}
+				if err != nil {
+					logger.Error(err, "Failed to get backend during cleanup, allowing deletion to proceed")
+					r.Recorder.Event(bmcSecret, "Warning", "CleanupFailed", "Backend unavailable during cleanup")
+					continue
+				}
+				defaultBackend, defaultPolicy = backend, policy
+			}
+			backend, err := backendAt(backendPath, defaultBackend, defaultLocation)
+			if err != nil {
+				logger.Error(err, "Failed to get backend at previous location", "path", backendPath.Path)
+				r.Recorder.Eventf(bmcSecret, "Warning", "CleanupFailed", "Backend at %s is unavailable, %s was not deleted", backendPath.Address, backendPath.Path)
+				continue
+			}
+			targets = append(targets, deletionTarget{
+				backend:  backend,
+				path:     backendPath.Path,
+				location: pathLocation(backendPath),
+				policy:   defaultPolicy,
+				synced:   backendPath.SyncStatus == "Success",
+			})
+			continue
+		}
+
+		if engineBackends == nil {
+			engineBackends = make(map[string]*secretbackend.EngineBackend)
+			engines, err := r.BackendFactory.GetAllEngineBackends(ctx)
+			if err != nil {
+				logger.Error(err, "Failed to get engine backends during cleanup, allowing deletion to proceed")
+				r.Recorder.Event(bmcSecret, "Warning", "CleanupFailed", "Engine backends unavailable during cleanup")
+			}
+			for _, engine := range engines {
+				engineBackends[engine.EngineName] = engine
+			}
+			for _, engineErr := range secretbackend.EngineErrors(err) {
+				unavailable[engineErr.Engine] = true
+			}
+		}
+
+		engine, ok := engineBackends[backendPath.Engine]
+		if !ok && unavailable[backendPath.Engine] {
+			r.Recorder.Eventf(bmcSecret, "Warning", "CleanupFailed", "Secret engine %s is unavailable, %s was not deleted", backendPath.Engine, backendPath.Path)
+			continue
+		}
+		if !ok {
+			logger.Info("Secret engine no longer configured, skipping cleanup", "path", backendPath.Path, "engine", backendPath.Engine)
+			r.Recorder.Eventf(bmcSecret, "Warning", "CleanupFailed", "Secret engine %s is no longer configured, %s was not deleted", backendPath.Engine, backendPath.Path)
+			continue
+		}
+		backend, err := backendAt(backendPath, engine.Backend, engine.Location)
+		if err != nil {
+			logger.Error(err, "Failed to get backend at previous location", "path", backendPath.Path, "engine", backendPath.Engine)
+			r.Recorder.Eventf(bmcSecret, "Warning", "CleanupFailed", "Secret engine %s is unavailable at %s, %s was not deleted",
+				backendPath.Engine, backendPath.Address, backendPath.Path)
+			continue
+		}
+		targets = append(targets, deletionTarget{
+			backend:  backend,
+			engine:   backendPath.Engine,
+			path:     backendPath.Path,
+			location: pathLocation(backendPath),
+			policy:   engine.DeletionPolicy,
+			synced:   backendPath.SyncStatus == "Success",
+		})
+	}
+
+	return targets, closeBackends
+}
+
+// defaultBackend returns the backend used without secret engines together with
+// its deletion policy
+func (r *BMCSecretReconciler) defaultBackend(ctx context.Context) (secretbackend.Backend, string, error) {
+	backend, err := r.BackendFactory.GetBackend(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	policy, err := r.BackendFactory.GetDeletionPolicy(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return backend, policy, nil
+}
+
+// computedDeletionTargets rebuilds the backend paths from the current BMCs and
+// configuration, for BMCSecrets without a recorded sync status
+func (r *BMCSecretReconciler) computedDeletionTargets(ctx context.Context, bmcSecret *metalv1alpha1.BMCSecret) []deletionTarget {
+	logger := log.FromContext(ctx)
+
+	regionLabelKey, err := r.BackendFactory.GetRegionLabelKey(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get region label key during cleanup")
+		return nil
+	}
+
+	// Extract credentials
+	username, _, err := bmcresolver.ExtractCredentials(bmcSecret)
+	if err != nil {
+		logger.Error(err, "Failed to extract credentials during cleanup, proceeding with deletion")
+		return nil
+	}
+
+	// Find associated BMCs
+	bmcs, err := r.findBMCs(ctx, bmcSecret.Name)
+	if err != nil {
+		logger.Error(err, "Failed to find BMCs during cleanup")
+		return nil
+	}
+
+	// Collect the backends to clean up together with their path builders
+	var engines []*secretbackend.EngineBackend
+	hasMultiEngine, err := r.BackendFactory.HasMultiEngineConfig(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to check multi-engine configuration during cleanup")
+		return nil
+	}
+	if hasMultiEngine {
+		engines, err = r.BackendFactory.GetEngineBackends(ctx, bmcSecret.Labels)
+		if err != nil {
+			logger.Error(err, "Failed to get engine backends during cleanup, allowing deletion to proceed")
+			r.Recorder.Event(bmcSecret, "Warning", "CleanupFailed", "Engine backends unavailable during cleanup")
+			// The engines that are available are still cleaned up
+			if len(secretbackend.EngineErrors(err)) == 0 {
+				return nil
+			}
+		}
+	} else {
+		backend, policy, err := r.defaultBackend(ctx)
+		if err != nil {
+			logger.Error(err, "Failed to get backend during cleanup, allowing deletion to proceed")
+			r.Recorder.Event(bmcSecret, "Warning", "CleanupFailed", "Backend unavailable during cleanup")
+			return nil
+		}
+		pathBuilder, err := r.BackendFactory.GetPathBuilder(ctx)
+		if err != nil {
+			logger.Error(err, "Failed to get path builder during cleanup")
+			return nil
+		}
+		engines = []*secretbackend.EngineBackend{{Backend: backend, PathBuilder: pathBuilder, DeletionPolicy: policy}}
+	}
+
+	var targets []deletionTarget
+	for _, engine := range engines {
+		for _, bmc := range bmcs {
+			region := bmcresolver.ExtractRegionFromBMC(&bmc, regionLabelKey)
+			hostname := bmcresolver.GetHostnameFromBMC(&bmc)
+
+			path, err := engine.PathBuilder.Build(pathVariables(&bmc, bmcSecret.Name, region, hostname, username))
+			if err != nil {
+				logger.Error(err, "Failed to build path during cleanup", "bmc", bmc.Name, "engine", engine.EngineName)
+				continue
+			}
+
+			targets = append(targets, deletionTarget{
+				backend: engine.Backend,
+				engine:  engine.EngineName,
+				path:    path,
+				policy:  engine.DeletionPolicy,
+			})
+		}
+	}
+
+	return targets
+}
+
+// pathVariables returns the path template variables of a BMC and the BMCSecret it references
+func pathVariables(bmc *metalv1alpha1.BMC, secretName, region, hostname, username string) secretbackend.PathVariables {
+	macAddress, ip := bmcresolver.GetAddressFromBMC(bmc)
+	return secretbackend.PathVariables{
+		Region:      region,
+		Hostname:    hostname,
+		Username:    username,
+		BMCName:     bmc.Name,
+		SecretName:  secretName,
+		Labels:      bmc.Labels,
+		Annotations: bmc.Annotations,
+		Protocol:    string(bmc.Spec.Protocol.Name),
+		Port:        bmc.Spec.Protocol.Port,
+		MACAddress:  macAddress,
+		IP:          ip,
+	}
+}
+
+// pruneOrphans applies the orphan policy to previously synced paths that are not
+// part of desired and returns the orphans that remain in the backend
+func (r *BMCSecretReconciler) pruneOrphans(
+	ctx context.Context,
+	bmcSecret *metalv1alpha1.BMCSecret,
+	desired []configv1alpha1.BackendPath,
+) []configv1alpha1.BackendPath {
+	logger := log.FromContext(ctx)
+
+	previous, err := r.getSyncStatus(ctx, bmcSecret.Name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to get BMCSecretSyncStatus for orphan detection")
+		}
+		return nil
+	}
+
+	desiredKeys := make(map[string]bool, len(desired))
+	locations := make(map[string]secretbackend.Location)
+	for _, backendPath := range desired {
+		desiredKeys[backendPathKey(backendPath)] = true
+		if backendPath.MountPath != "" {
+			locations[backendPath.Engine] = pathLocation(backendPath)
+		}
+	}
+	previousOrphans := locateBackendPaths(previous.Status.OrphanedPaths, locations)
+	reported := make(map[string]bool, len(previousOrphans))
+	for _, backendPath := range previousOrphans {
+		reported[backendPathKey(backendPath)] = true
+	}
+
+	var orphans []configv1alpha1.BackendPath
+	seen := make(map[string]bool)
+	for _, backendPath := range slices.Concat(locateBackendPaths(previous.Status.BackendPaths, locations), previousOrphans) {
+		key := backendPathKey(backendPath)
+		if backendPath.Path == "" || backendPath.SyncStatus == "Conflict" || desiredKeys[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		orphans = append(orphans, backendPath)
+	}
+
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	policy, err := r.BackendFactory.GetOrphanPolicy(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get orphan policy, keeping orphaned paths")
+		return orphans
+	}
+
+	switch policy {
+	case secretbackend.OrphanPolicyRetain:
+		logger.V(1).Info("Retaining orphaned backend paths", "count", len(orphans))
+		return nil
+	case secretbackend.OrphanPolicyReport:
+		for _, orphan := range orphans {
+			if !reported[backendPathKey(orphan)] {
+				r.Recorder.Eventf(bmcSecret, "Warning", "OrphanDetected", "Backend path %s is no longer desired and was retained", describeBackendPath(orphan))
+			}
+		}
+		return orphans
+	}
+
+	owner, adoptPolicy, err := r.secretOwner(ctx, bmcSecret.Name)
+	if err != nil {
+		logger.Error(err, "Failed to get ownership configuration, keeping orphaned paths")
+		return orphans
+	}
+
+	targets, closeBackends := r.recordedDeletionTargets(ctx, bmcSecret, orphans)
+	defer closeBackends()
+
+	deleted := make(map[string]bool, len(orphans))
+	for _, target := range targets {
+		orphan := target.backendPath()
+		if target.policy == secretbackend.DeletionPolicyRetain {
+			// The deletion policy forbids removing it, so the path is only released
+			logger.Info("Releasing orphaned backend path retained per deletion policy", "path", target.path, "engine", target.engine)
+			r.Recorder.Eventf(bmcSecret, "Normal", "OrphanRetained", "Orphaned backend path %s was retained per deletion policy", describeBackendPath(orphan))
+			deleted[backendPathKey(orphan)] = true
+			continue
+		}
+		err := r.deleteOwnedSecret(ctx, target, owner, adoptPolicy)
+		if goerrors.Is(err, secretbackend.ErrOwnershipConflict) {
+			// The path was taken over by someone else, so it is no longer ours to prune
+			logger.Info("Releasing orphaned backend path not owned by this BMCSecret", "path", target.path, "engine", target.engine, "reason", err.Error())
+			r.Recorder.Eventf(bmcSecret, "Warning", "OwnershipConflict", "Orphaned backend path %s was not deleted: %v", describeBackendPath(orphan), err)
+			deleted[backendPathKey(orphan)] = true
+			continue
+		}
+		if err != nil {
+			logger.Error(err, "Failed to delete orphaned backend path", "path", target.path, "engine", target.engine)
+			r.Recorder.Eventf(bmcSecret, "Warning", "OrphanPruneFailed", "Failed to delete orphaned backend path %s: %v", describeBackendPath(orphan), err)
+			continue
+		}
+
+		logger.Info("Deleted orphaned backend path", "path", target.path, "engine", target.engine, "policy", target.policy)
+		r.Recorder.Eventf(bmcSecret, "Normal", "OrphanPruned", "Deleted orphaned backend path %s", describeBackendPath(orphan))
+		deleted[backendPathKey(orphan)] = true
+	}
+
+	var remaining []configv1alpha1.BackendPath
+	for _, orphan := range orphans {
+		if !deleted[backendPathKey(orphan)] {
+			remaining = append(remaining, orphan)
+		}
+	}
+	return remaining
+}
+
+// pruneAllPaths treats every previously synced path as orphaned, for BMCSecrets
+// that no longer have any desired path, and returns the orphans still tracked
+func (r *BMCSecretReconciler) pruneAllPaths(ctx context.Context, bmcSecret *metalv1alpha1.BMCSecret) []configv1alpha1.BackendPath {
+	if _, err := r.getSyncStatus(ctx, bmcSecret.Name); err != nil {
+		return nil
+	}
+
+	orphanedPaths := r.pruneOrphans(ctx, bmcSecret, nil)
+	if err := r.updateSyncStatus(ctx, bmcSecret.Name, nil, orphanedPaths, 0, 0, 0); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update sync status")
+	}
+	return orphanedPaths
+}
+
+// releaseOutOfScope handles a BMCSecret not matching the sync selector of the configuration.
+// If it was synced before, its paths are orphaned and handled by the orphan and deletion
+// policies. It reports whether no orphan is tracked anymore, so the finalizer is only
+// removed once orphans kept for reporting or after failed deletes are gone.
+func (r *BMCSecretReconciler) releaseOutOfScope(ctx context.Context, bmcSecret *metalv1alpha1.BMCSecret) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	syncStatus, err := r.getSyncStatus(ctx, bmcSecret.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		logger.Error(err, "Failed to get BMCSecretSyncStatus")
+		return false, err
+	}
+
+	if len(syncStatus.Status.BackendPaths) > 0 {
+		logger.Info("BMCSecret left the sync scope, releasing synced paths")
+		r.Recorder.Event(bmcSecret, "Normal", "OutOfScope",
+			"BMCSecret no longer matches the sync selector, synced paths are handled as orphans")
+	}
+
+	if orphanedPaths := r.pruneAllPaths(ctx, bmcSecret); len(orphanedPaths) > 0 {
+		return false, nil
+	}
+
+	if err := r.Delete(ctx, syncStatus); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete BMCSecretSyncStatus")
+		return false, err
+	}
+	return true, nil
+}
+
+// backendPathKey identifies a backend path across secret engines and the locations
+// their backends stored it at
+func backendPathKey(backendPath configv1alpha1.BackendPath) string {
+	return strings.Join([]string{
+		backendPath.Engine, backendPath.Address, backendPath.Namespace, backendPath.MountPath, backendPath.Path,
+	}, "\x00")
+}
+
+// pathLocation returns the location a backend path was recorded at
+func pathLocation(backendPath configv1alpha1.BackendPath) secretbackend.Location {
+	return secretbackend.Location{
+		Address:   backendPath.Address,
+		Namespace: backendPath.Namespace,
+		MountPath: backendPath.MountPath,
+	}
+}
+
+// locateBackendPaths returns the backend paths with the ones recorded before their
+// location was placed at the location of their engine, where they were synced to
+// unless the location changed since
+func locateBackendPaths(backendPaths []configv1alpha1.BackendPath, locations map[string]secretbackend.Location) []configv1alpha1.BackendPath {
+	located := slices.Clone(backendPaths)
+	for i := range located {
+		location, ok := locations[located[i].Engine]
+		if located[i].MountPath != "" || !ok {
+			continue
+		}
+		located[i].Address = location.Address
+		located[i].Namespace = location.Namespace
+		located[i].MountPath = location.MountPath
+	}
+	return located
+}
+
+// backendPathField is the field index on the backend paths a BMCSecretSyncStatus
+// synced successfully, prefixed with its configuration
+const backendPathField = "status.backendPaths.path"
+
+// indexBackendPaths returns the successfully synced paths of a BMCSecretSyncStatus
+// for the backendPathField index
+func indexBackendPaths(obj client.Object) []string {
+	syncStatus, ok := obj.(*configv1alpha1.BMCSecretSyncStatus)
+	if !ok {
+		return nil
+	}
+
+	var keys []string
+	for _, backendPath := range syncStatus.Status.BackendPaths {
+		if backendPath.SyncStatus == "Success" {
+			keys = append(keys, syncStatus.Spec.ConfigRef+"/"+backendPathKey(backendPath))
+		}
+	}
+	return keys
+}
+
+// describeBackendPath formats a backend path for events
+func describeBackendPath(backendPath configv1alpha1.BackendPath) string {
+	if backendPath.Engine == "" {
+		return backendPath.Path
+	}
+	return fmt.Sprintf("%s (engine %s)", backendPath.Path, backendPath.Engine)
+}
+
+// needsUpdate checks if the secret needs to be updated in the backend and returns the
+// secret currently stored, nil if there is none. It returns an error wrapping
+// secretbackend.ErrOwnershipConflict if owner may not overwrite the secret.
+func (r *BMCSecretReconciler) needsUpdate(
+	ctx context.Context,
+	backend secretbackend.Backend,
+	path string,
+	desired map[string]any,
+	owner secretbackend.Owner,
+	adoptPolicy string,
+) (bool, *secretbackend.Secret, error) {
+	// A single read tells both whether the secret exists and what it holds.
+	// Missing secrets are written without check-and-set, as a deleted KV v2
+	// version still counts as the current one.
+	current, err := backend.ReadSecret(ctx, path)
+	if goerrors.Is(err, secretbackend.ErrSecretNotFound) {
+		return true, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+
+	if err := secretbackend.CheckOwnership(current, owner, adoptPolicy); err != nil {
+		return false, nil, err
+	}
+
+	// Compare the full data, so changed usernames and extra fields are overwritten too.
+	// Adopted secrets get a marker. The config in the marker is informational only, so
+	// configs syncing the same path don't rewrite each other's marker on every verification.
+	recorded, marked := secretbackend.OwnerFromMetadata(current.Metadata)
+	return !reflect.DeepEqual(current.Data, desired) || !marked || !recorded.Matches(owner), current, nil
+}
+
+// deleteOwnedSecret deletes the secret at the target as given by its deletion policy
+// unless it belongs to someone else, returning an error wrapping
+// secretbackend.ErrOwnershipConflict in that case
+func (r *BMCSecretReconciler) deleteOwnedSecret(
+	ctx context.Context,
+	target deletionTarget,
+	owner secretbackend.Owner,
+	adoptPolicy string,
+) error {
+	current, err := target.backend.ReadSecret(ctx, target.path)
+	if err != nil && !goerrors.Is(err, secretbackend.ErrSecretNotFound) {
+		return err
+	}
+	if err == nil {
+		if target.synced {
+			adoptPolicy = secretbackend.AdoptPolicyAdopt
+		}
+		if err := secretbackend.CheckOwnership(current, owner, adoptPolicy); err != nil {
+			return err
+		}
+	}
+
+	return target.backend.DeleteSecret(ctx, target.path, secretbackend.DeleteOptions{
+		SoftDelete: target.policy == secretbackend.DeletionPolicySoftDelete,
+	})
+}
+
+// findBMCs returns the BMCs referencing the given BMCSecret and records the lookup time
+func (r *BMCSecretReconciler) findBMCs(ctx context.Context, secretName string) ([]metalv1alpha1.BMC, error) {
+	startTime := time.Now()
+	bmcs, err := bmcresolver.FindBMCsForSecret(ctx, r.Client, secretName)
+	if r.Metrics != nil {
+		r.Metrics.RecordBMCDiscovery(secretName, time.Since(startTime))
+	}
+	return bmcs, err
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *BMCSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index BMCs by the referenced BMCSecret so lookups don't list all BMCs
+	if err := bmcresolver.SetupBMCSecretRefIndex(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return fmt.Errorf("failed to index BMCs by BMCSecret reference: %w", err)
+	}
+	// Index sync statuses by their paths to find BMCSecrets syncing to the same path
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths); err != nil {
+		return fmt.Errorf("failed to index BMCSecretSyncStatuses by backend path: %w", err)
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.BMCSecret{}, builder.WithPredicates(r.syncScopePredicate())).
+		Watches(
+			&metalv1alpha1.BMC{},
+			r.bmcEventHandler(),
+		)
+
+	// Re-evaluate every BMCSecret when the backend configuration changes
+	if r.ConfigChanges != nil {
+		controllerBuilder = controllerBuilder.WatchesRawSource(source.Channel(
+			r.ConfigChanges,
+			handler.EnqueueRequestsFromMapFunc(r.findAllBMCSecrets),
+		))
+	}
+
+	return controllerBuilder.Complete(r)
+}
+
+// syncScopePredicate admits BMCSecrets matching the sync selector of any current
+// configuration. The selectors are looked up on every event, so configuration changes
+// apply without a restart. With a registry, the selectors are parsed from the
+// SecretBackendConfigs and cached per generation, so events don't load configurations.
+// BMCSecrets with the finalizer are always admitted so they are released when they stop
+// matching.
+func (r *BMCSecretReconciler) syncScopePredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(object client.Object) bool {
+		if controllerutil.ContainsFinalizer(object, bmcSecretFinalizer) {
+			return true
+		}
+		selectors, err := r.syncSelectors(context.Background())
+		if err != nil {
+			// Let the reconciler report the configuration error and retry
+			return true
+		}
+		for _, syncSelector := range selectors {
+			// Invalid selectors are nil, the reconciler reports them
+			if syncSelector == nil || syncSelector.Matches(labels.Set(object.GetLabels())) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// syncSelectors returns the sync selector of every configuration, nil for a
+// configuration whose selector cannot be determined
+func (r *BMCSecretReconciler) syncSelectors(ctx context.Context) ([]labels.Selector, error) {
+	if r.Registry != nil {
+		selectors, err := r.Registry.SyncSelectors(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return slices.Collect(maps.Values(selectors)), nil
+	}
+
+	syncSelector, err := r.BackendFactory.GetSyncSelector(ctx)
+	if err != nil {
+		return []labels.Selector{nil}, nil
+	}
+	return []labels.Selector{syncSelector}, nil
+}
+
+// findAllBMCSecrets enqueues every BMCSecret, used when the configuration changes
+func (r *BMCSecretReconciler) findAllBMCSecrets(ctx context.Context, _ client.Object) []reconcile.Request {
+	var bmcSecrets metalv1alpha1.BMCSecretList
+	if err := r.List(ctx, &bmcSecrets); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list BMCSecrets")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(bmcSecrets.Items))
+	for _, bmcSecret := range bmcSecrets.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: bmcSecret.Name},
+		})
+	}
+	return requests
+}
+
+// bmcEventHandler enqueues the BMCSecret referenced by a changed BMC. When a BMC
+// switches secrets, the previously referenced BMCSecret is enqueued as well so it
+// drops the paths synced for that BMC.
+func (r *BMCSecretReconciler) bmcEventHandler() handler.EventHandler {
+	enqueue := func(
+		ctx context.Context,
+		queue workqueue.TypedRateLimitingInterface[reconcile.Request],
+		objs ...client.Object,
+	) {
+		for _, obj := range objs {
+			for _, req := range r.findBMCSecretsForBMC(ctx, obj) {
+				queue.Add(req)
+			}
+		}
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, queue, e.Object)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, queue, e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, queue, e.Object)
+		},
+		GenericFunc: func(ctx context.Context, e event.GenericEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, queue, e.Object)
+		},
+	}
 }
 
 // findBMCSecretsForBMC finds BMCSecrets that should be reconciled when a BMC changes
 func (r *BMCSecretReconciler) findBMCSecretsForBMC(ctx context.Context, obj client.Object) []reconcile.Request {
-	bmc := obj.(*metalv1alpha1.BMC)
+	bmc, ok := obj.(*metalv1alpha1.BMC)
+	if !ok || bmc.Spec.BMCSecretRef.Name == "" {
+		return nil
+	}
 	return []reconcile.Request{
 		{NamespacedName: types.NamespacedName{Name: bmc.Spec.BMCSecretRef.Name}},
 	}
 }
 
 // updateSyncStatus creates or updates the BMCSecretSyncStatus resource
-func (r *BMCSecretReconciler) updateSyncStatus(ctx context.Context, bmcSecretName string, backendPaths []configv1alpha1.BackendPath, totalPaths, successfulPaths, failedPaths int) error {
+func (r *BMCSecretReconciler) updateSyncStatus(
+	ctx context.Context,
+	bmcSecretName string,
+	backendPaths, orphanedPaths []configv1alpha1.BackendPath,
+	totalPaths, successfulPaths, failedPaths int,
+) error {
 	logger := log.FromContext(ctx)
 
-	syncStatusName := fmt.Sprintf("%s-sync-status", bmcSecretName)
-	syncStatus := &configv1alpha1.BMCSecretSyncStatus{}
-
-	// Try to get existing status
-	err := r.Get(ctx, types.NamespacedName{Name: syncStatusName}, syncStatus)
+	syncStatus, err := r.getSyncStatus(ctx, bmcSecretName)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			return err
@@ -705,10 +1864,12 @@ func (r *BMCSecretReconciler) updateSyncStatus(ctx context.Context, bmcSecretNam
 		// Create new status resource
 		syncStatus = &configv1alpha1.BMCSecretSyncStatus{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: syncStatusName,
+				Name:   r.syncStatusName(bmcSecretName),
+				Labels: r.syncStatusLabels(bmcSecretName),
 			},
 			Spec: configv1alpha1.BMCSecretSyncStatusSpec{
 				BMCSecretRef: bmcSecretName,
+				ConfigRef:    r.configName,
 			},
 		}
 
@@ -718,13 +1879,26 @@ func (r *BMCSecretReconciler) updateSyncStatus(ctx context.Context, bmcSecretNam
 		}
 
 		// Fetch the created resource to update status
-		if err := r.Get(ctx, types.NamespacedName{Name: syncStatusName}, syncStatus); err != nil {
+		if err := r.Get(ctx, types.NamespacedName{Name: syncStatus.Name}, syncStatus); err != nil {
+			return err
+		}
+	} else if syncStatus.Spec.ConfigRef != r.configName || !labels.SelectorFromSet(r.syncStatusLabels(bmcSecretName)).Matches(labels.Set(syncStatus.Labels)) {
+		// Statuses created before they were labeled, or by a reconciler of the default
+		// configuration under another name, are adopted by this configuration
+		if syncStatus.Labels == nil {
+			syncStatus.Labels = make(map[string]string)
+		}
+		maps.Copy(syncStatus.Labels, r.syncStatusLabels(bmcSecretName))
+		syncStatus.Spec.ConfigRef = r.configName
+		if err := r.Update(ctx, syncStatus); err != nil {
+			logger.Error(err, "Failed to update BMCSecretSyncStatus")
 			return err
 		}
 	}
 
 	// Update status
 	syncStatus.Status.BackendPaths = backendPaths
+	syncStatus.Status.OrphanedPaths = orphanedPaths
 	syncStatus.Status.LastSyncAttempt = metav1.Now()
 	syncStatus.Status.TotalPaths = totalPaths
 	syncStatus.Status.SuccessfulPaths = successfulPaths
@@ -761,8 +1935,8 @@ func (r *BMCSecretReconciler) updateSyncStatus(ctx context.Context, bmcSecretNam
 		}
 	}
 
-	// Update or add condition
-	setCondition(&syncStatus.Status.Conditions, condition)
+	// Update or add the condition, keeping its transition time while the status is unchanged
+	apimeta.SetStatusCondition(&syncStatus.Status.Conditions, condition)
 
 	if err := r.Status().Update(ctx, syncStatus); err != nil {
 		logger.Error(err, "Failed to update BMCSecretSyncStatus status")
@@ -771,23 +1945,3 @@ func (r *BMCSecretReconciler) updateSyncStatus(ctx context.Context, bmcSecretNam
 
 	return nil
 }
-
-// setCondition updates or adds a condition to the conditions slice
-func setCondition(conditions *[]metav1.Condition, newCondition metav1.Condition) {
-	if conditions == nil {
-		*conditions = []metav1.Condition{}
-	}
-
-	for i, condition := range *conditions {
-		if condition.Type == newCondition.Type {
-			// Update existing condition if status changed
-			if condition.Status != newCondition.Status || condition.Reason != newCondition.Reason {
-				(*conditions)[i] = newCondition
-			}
-			return
-		}
-	}
-
-	// Add new condition
-	*conditions = append(*conditions, newCondition)
-}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -32,9 +33,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/ironcore-dev/bmc-secret-operator/internal/controller/bmcresolver"
 	"github.com/ironcore-dev/bmc-secret-operator/internal/controller/mock"
 )
 
@@ -42,6 +47,13 @@ const (
 	testBMCHostname = "bmc-server1.example.com"
 )
 
+// ownedBy returns write options setting the ownership marker of the BMCSecret
+func ownedBy(bmcSecretName string) secretbackend.WriteOptions {
+	return secretbackend.WriteOptions{
+		Metadata: secretbackend.Owner{BMCSecret: bmcSecretName, Config: secretbackend.DefaultBackendConfigName}.Metadata(),
+	}
+}
+
 func TestBMCSecretController(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "BMCSecret Controller Suite")
@@ -113,6 +125,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				Build()
 
@@ -132,6 +146,7 @@ var _ = Describe("BMCSecret Controller", func() {
 			Expect(mockBackend.WriteSecretCalls[0].Path).To(Equal("bmc/us-east-1/bmc-server1.example.com/admin"))
 			Expect(mockBackend.WriteSecretCalls[0].Data["username"]).To(Equal("admin"))
 			Expect(mockBackend.WriteSecretCalls[0].Data["password"]).To(Equal("secret123"))
+			Expect(mockBackend.WriteSecretCalls[0].Metadata).To(Equal(ownedBy("test-secret").Metadata))
 		})
 
 		It("Should sync to multiple paths when multiple BMCs reference the same secret", func() {
@@ -177,6 +192,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc1, bmc2).
 				Build()
 
@@ -234,6 +251,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				Build()
 
@@ -285,6 +304,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				Build()
 
@@ -316,6 +337,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret).
 				Build()
 
@@ -362,6 +385,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				Build()
 
@@ -407,6 +432,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				Build()
 
@@ -451,6 +478,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				Build()
 
@@ -500,6 +529,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				Build()
 
@@ -519,10 +550,10 @@ var _ = Describe("BMCSecret Controller", func() {
 		})
 
 		It("Should not update backend if password is unchanged", func() {
-			err := mockBackend.WriteSecret(ctx, "bmc/us-east-1/bmc-server1.example.com/admin", map[string]any{
+			_, err := mockBackend.WriteSecret(ctx, "bmc/us-east-1/bmc-server1.example.com/admin", map[string]any{
 				"username": "admin",
 				"password": "secret123",
-			})
+			}, ownedBy("unchanged-secret"))
 			Expect(err).NotTo(HaveOccurred())
 
 			bmcSecret := &metalv1alpha1.BMCSecret{
@@ -552,6 +583,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				Build()
 
@@ -573,10 +606,10 @@ var _ = Describe("BMCSecret Controller", func() {
 		})
 
 		It("Should update backend when password changes", func() {
-			err := mockBackend.WriteSecret(ctx, "bmc/us-east-1/bmc-server1.example.com/admin", map[string]any{
+			_, err := mockBackend.WriteSecret(ctx, "bmc/us-east-1/bmc-server1.example.com/admin", map[string]any{
 				"username": "admin",
 				"password": "oldpassword",
-			})
+			}, ownedBy("changed-secret"))
 			Expect(err).NotTo(HaveOccurred())
 
 			bmcSecret := &metalv1alpha1.BMCSecret{
@@ -606,6 +639,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				Build()
 
@@ -625,14 +660,16 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			Expect(mockBackend.GetWriteCallCount()).To(Equal(initialWrites + 1))
 
-			data, err := mockBackend.ReadSecret(ctx, "bmc/us-east-1/bmc-server1.example.com/admin")
+			secret, err := mockBackend.ReadSecret(ctx, "bmc/us-east-1/bmc-server1.example.com/admin")
 			Expect(err).NotTo(HaveOccurred())
-			Expect(data["password"]).To(Equal("newpassword"))
+			Expect(secret.Data["password"]).To(Equal("newpassword"))
 		})
 
 		It("Should handle nonexistent BMCSecret", func() {
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				Build()
 
 			reconciler = &BMCSecretReconciler{
@@ -678,6 +715,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
 				Build()
@@ -740,6 +779,8 @@ var _ = Describe("BMCSecret Controller", func() {
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
 				WithObjects(bmcSecret, bmc).
 				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
 				Build()
@@ -768,227 +809,1790 @@ var _ = Describe("BMCSecret Controller", func() {
 			Expect(syncStatus.Status.BackendPaths[0].SyncStatus).To(Equal("Failed"))
 			Expect(syncStatus.Status.BackendPaths[0].ErrorMessage).To(ContainSubstring("backend connection failed"))
 		})
-	})
-})
 
-var _ = Describe("BMCSecret Multi-Engine Controller", func() {
-	var ctx context.Context
+		It("Should update the Synced condition message when the number of paths changes", func() {
+			bmcSecret := &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "growing-secret"},
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("secret123"),
+				},
+			}
 
-	BeforeEach(func() {
-		ctx = context.Background()
-	})
+			newBMC := func(name, hostname string) *metalv1alpha1.BMC {
+				return &metalv1alpha1.BMC{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   name,
+						Labels: map[string]string{"region": "us-east-1"},
+					},
+					Spec: metalv1alpha1.BMCSpec{
+						BMCSecretRef: corev1.LocalObjectReference{Name: "growing-secret"},
+						Hostname:     &hostname,
+						Protocol:     metalv1alpha1.Protocol{Name: metalv1alpha1.ProtocolNameRedfish},
+					},
+				}
+			}
 
-	Context("Single engine label match", func() {
-		It("Should match engine with exact label value", func() {
-			engines := []configv1alpha1.SecretEngineConfig{
-				{
-					Name:         "team-a",
-					MountPath:    "secret",
-					PathTemplate: "bmc/team-a/{{.Region}}/{{.Hostname}}/{{.Username}}",
-					SyncLabel:    "team=a",
-				},
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(bmcSecret, newBMC("bmc-1", "bmc1.example.com")).
+				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
+				Build()
+
+			reconciler = &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: mockBackendFactory,
 			}
-			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
-			Expect(err).NotTo(HaveOccurred())
 
-			labels := map[string]string{"team": "a"}
-			matchingEngines, err := multiEngineFactory.GetMatchingEngines(ctx, labels)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(matchingEngines).To(HaveLen(1))
-			Expect(matchingEngines[0].Name).To(Equal("team-a"))
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "growing-secret"}}
+			Expect(reconciler.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+			Expect(k8sClient.Create(ctx, newBMC("bmc-2", "bmc2.example.com"))).To(Succeed())
+			Expect(reconciler.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+			syncStatus := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "growing-secret-sync-status"}, syncStatus)).To(Succeed())
+			Expect(syncStatus.Status.Conditions).To(HaveLen(1))
+			Expect(syncStatus.Status.Conditions[0].Reason).To(Equal("AllPathsSynced"))
+			Expect(syncStatus.Status.Conditions[0].Message).To(Equal("Successfully synced to 2 backend paths"))
 		})
 	})
 
-	Context("Multiple engine label matches", func() {
-		It("Should match all matching engines", func() {
-			engines := []configv1alpha1.SecretEngineConfig{
-				{
-					Name:      "team-a",
-					MountPath: "team-a-secret",
-					SyncLabel: "team=a",
+	Context("When checking the backend for drift", func() {
+		const driftPath = "bmc/us-east-1/bmc-server1.example.com/admin"
+
+		reconcileDrift := func() reconcile.Result {
+			bmcSecret := &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "drift-secret"},
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("secret123"),
 				},
-				{
-					Name:      "critical-prod",
-					MountPath: "critical-secret",
-					SyncLabel: "critical=true",
+			}
+
+			hostname := testBMCHostname
+			bmc := &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-bmc",
+					Labels: map[string]string{"region": "us-east-1"},
+				},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "drift-secret"},
+					Hostname:     &hostname,
 				},
 			}
-			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
-			Expect(err).NotTo(HaveOccurred())
 
-			labels := map[string]string{"team": "a", "critical": "true"}
-			matchingEngines, err := multiEngineFactory.GetMatchingEngines(ctx, labels)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(matchingEngines).To(HaveLen(2))
-		})
-	})
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(bmcSecret, bmc).
+				Build()
 
-	Context("Label matching - exact vs any value", func() {
-		It("Should match exact label values (key=value)", func() {
-			engines := []configv1alpha1.SecretEngineConfig{
-				{
-					Name:      "exact-match",
-					MountPath: "secret",
-					SyncLabel: "env=prod",
-				},
+			reconciler = &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: mockBackendFactory,
 			}
-			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
-			Expect(err).NotTo(HaveOccurred())
 
-			labels1 := map[string]string{"env": "prod"}
-			matching, err := multiEngineFactory.GetMatchingEngines(ctx, labels1)
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "drift-secret"},
+			})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(matching).To(HaveLen(1))
+			return result
+		}
 
-			labels2 := map[string]string{"env": "dev"}
-			matching, err = multiEngineFactory.GetMatchingEngines(ctx, labels2)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(matching).To(BeEmpty())
+		storeSecret := func(data map[string]any) {
+			Expect(mockBackend.WriteSecret(ctx, driftPath, data, ownedBy("drift-secret"))).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+		}
+
+		It("Should read the secret exactly once when it is up to date", func() {
+			storeSecret(map[string]any{"username": "admin", "password": "secret123"})
+
+			reconcileDrift()
+
+			Expect(mockBackend.ReadSecretCalls).To(ConsistOf(driftPath))
+			Expect(mockBackend.SecretExistsCalls).To(BeEmpty())
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
 		})
 
-		It("Should match any value for key-only labels", func() {
-			engines := []configv1alpha1.SecretEngineConfig{
-				{
-					Name:      "any-value-match",
-					MountPath: "secret",
-					SyncLabel: "sync-enabled",
-				},
-			}
-			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
-			Expect(err).NotTo(HaveOccurred())
+		It("Should write the secret after a single read when it does not exist", func() {
+			reconcileDrift()
 
-			labels1 := map[string]string{"sync-enabled": "true"}
-			matching, err := multiEngineFactory.GetMatchingEngines(ctx, labels1)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(matching).To(HaveLen(1))
+			Expect(mockBackend.ReadSecretCalls).To(ConsistOf(driftPath))
+			Expect(mockBackend.SecretExistsCalls).To(BeEmpty())
+			Expect(mockBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", driftPath)))
+		})
 
-			labels2 := map[string]string{"sync-enabled": "yes"}
-			matching, err = multiEngineFactory.GetMatchingEngines(ctx, labels2)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(matching).To(HaveLen(1))
+		It("Should rewrite the secret when the username differs", func() {
+			storeSecret(map[string]any{"username": "root", "password": "secret123"})
 
-			labels3 := map[string]string{"other-label": "value"}
-			matching, err = multiEngineFactory.GetMatchingEngines(ctx, labels3)
+			reconcileDrift()
+
+			Expect(mockBackend.WriteSecretCalls).To(HaveLen(1))
+		})
+
+		It("Should rewrite the secret when it has extra fields", func() {
+			storeSecret(map[string]any{"username": "admin", "password": "secret123", "note": "added by hand"})
+
+			reconcileDrift()
+
+			Expect(mockBackend.WriteSecretCalls).To(HaveLen(1))
+			secret, err := mockBackend.ReadSecret(ctx, driftPath)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(matching).To(BeEmpty())
+			Expect(secret.Data).To(Equal(map[string]any{"username": "admin", "password": "secret123"}))
 		})
-	})
 
-	Context("Global syncLabel filtering", func() {
-		It("Should require global syncLabel when configured", func() {
-			engines := []configv1alpha1.SecretEngineConfig{
-				{
-					Name:      "team-a",
-					MountPath: "secret",
-					SyncLabel: "team=a",
-				},
+		It("Should fail the path when the read fails for another reason", func() {
+			storeSecret(map[string]any{"username": "admin", "password": "secret123"})
+			mockBackend.ReadError = fmt.Errorf("permission denied")
+
+			reconcileDrift()
+
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+		})
+
+		It("Should pass the version read as check-and-set", func() {
+			storeSecret(map[string]any{"username": "admin", "password": "old"})
+
+			reconcileDrift()
+
+			Expect(mockBackend.WriteSecretCalls).To(ConsistOf(HaveField("CAS", HaveValue(Equal(1)))))
+		})
+
+		It("Should not overwrite a secret changed concurrently and requeue", func() {
+			storeSecret(map[string]any{"username": "admin", "password": "old"})
+			mockBackend.BeforeWrite = func(string) {
+				mockBackend.BeforeWrite = nil
+				storeSecret(map[string]any{"username": "admin", "password": "concurrent"})
 			}
-			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "globally-sync", "region")
-			Expect(err).NotTo(HaveOccurred())
 
-			labels := map[string]string{"team": "a", "globally-sync": "true"}
-			matching, err := multiEngineFactory.GetMatchingEngines(ctx, labels)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(matching).To(HaveLen(1))
+			result := reconcileDrift()
 
-			labels2 := map[string]string{"team": "a"}
-			matching, err = multiEngineFactory.GetMatchingEngines(ctx, labels2)
+			Expect(result.RequeueAfter).To(Equal(requeueAfterConflict))
+			Expect(mockBackend.ReadSecretCalls).To(HaveLen(2))
+			secret, err := mockBackend.ReadSecret(ctx, driftPath)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(matching).To(BeEmpty())
+			Expect(secret.Data["password"]).To(Equal("concurrent"))
 		})
-	})
 
-	Context("Path templates per engine", func() {
-		It("Should apply different path templates for each engine", func() {
-			engines := []configv1alpha1.SecretEngineConfig{
-				{
-					Name:         "team-a",
-					MountPath:    "secret-a",
-					PathTemplate: "team-a/{{.Region}}/{{.Hostname}}/{{.Username}}",
-					SyncLabel:    "team=a",
-				},
-				{
-					Name:         "team-b",
-					MountPath:    "secret-b",
-					PathTemplate: "prod/{{.Region}}/bmc/{{.Hostname}}/user-{{.Username}}",
-					SyncLabel:    "team=b",
-				},
+		It("Should accept a concurrent write of the desired data", func() {
+			storeSecret(map[string]any{"username": "admin", "password": "old"})
+			mockBackend.BeforeWrite = func(string) {
+				mockBackend.BeforeWrite = nil
+				storeSecret(map[string]any{"username": "admin", "password": "secret123"})
 			}
-			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
-			Expect(err).NotTo(HaveOccurred())
 
-			builderA, err := multiEngineFactory.GetPathBuilderForEngine(ctx, "team-a")
-			Expect(err).NotTo(HaveOccurred())
+			result := reconcileDrift()
 
-			pathA, err := builderA.Build(secretbackend.PathVariables{
-				Region:   "us-east-1",
-				Hostname: "server1.com",
-				Username: "admin",
-			})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(pathA).To(Equal("team-a/us-east-1/server1.com/admin"))
+			Expect(result.RequeueAfter).To(Equal(requeueAfterNormal))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("Synced")))
 		})
 	})
 
-	Context("Mount paths per engine", func() {
-		It("Should track different mount paths for each engine", func() {
-			engines := []configv1alpha1.SecretEngineConfig{
-				{
-					Name:      "team-a",
-					MountPath: "kv-team-a",
-					SyncLabel: "team=a",
+	Context("When the backend secret is not owned by the BMCSecret", func() {
+		const ownedPath = "bmc/us-east-1/bmc-server1.example.com/admin"
+
+		var bmcSecret *metalv1alpha1.BMCSecret
+
+		BeforeEach(func() {
+			bmcSecret = &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "owned-secret",
+					Finalizers: []string{bmcSecretFinalizer},
 				},
-				{
-					Name:      "team-b",
-					MountPath: "kv-team-b",
-					SyncLabel: "team=b",
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("secret123"),
 				},
 			}
-			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
-			Expect(err).NotTo(HaveOccurred())
-
-			allEngines, err := multiEngineFactory.GetSecretEngines(ctx)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(allEngines).To(HaveLen(2))
 		})
-	})
 
-	Context("Error isolation between engines", func() {
-		It("Should continue syncing if one engine fails", func() {
-			engines := []configv1alpha1.SecretEngineConfig{
-				{
-					Name:      "backend-1",
-					MountPath: "secret",
-					SyncLabel: "env=prod",
+		reconcileOwned := func(objs ...client.Object) client.Client {
+			hostname := testBMCHostname
+			bmc := &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-bmc",
+					Labels: map[string]string{"region": "us-east-1"},
 				},
-				{
-					Name:      "backend-2",
-					MountPath: "secret",
-					SyncLabel: "env=prod",
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "owned-secret"},
+					Hostname:     &hostname,
 				},
 			}
-			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
-			Expect(err).NotTo(HaveOccurred())
 
-			backendMock1 := multiEngineFactory.GetMockBackendForEngine("backend-1")
-			backendMock1.WriteError = fmt.Errorf("connection error")
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(append(objs, bmcSecret, bmc)...).
+				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
+				Build()
 
-			labels := map[string]string{"env": "prod"}
-			matchingEngines, err := multiEngineFactory.GetMatchingEngines(ctx, labels)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(matchingEngines).To(HaveLen(2))
+			reconciler = &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: mockBackendFactory,
+			}
 
-			backendMock2 := multiEngineFactory.GetMockBackendForEngine("backend-2")
-			err = backendMock2.WriteSecret(ctx, "backend2/us-east-1/bmc-server/admin", map[string]any{
-				"username": "admin",
-				"password": "secret123",
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "owned-secret"},
 			})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(backendMock2.GetWriteCallCount()).To(Equal(1))
-		})
-	})
+			return k8sClient
+		}
 
-	Context("Backward compatibility", func() {
+		syncedPath := func(k8sClient client.Client) configv1alpha1.BackendPath {
+			syncStatus := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "owned-secret-sync-status"}, syncStatus)).To(Succeed())
+			Expect(syncStatus.Status.BackendPaths).To(HaveLen(1))
+			return syncStatus.Status.BackendPaths[0]
+		}
+
+		It("Should report a conflict for a secret owned by another BMCSecret", func() {
+			Expect(mockBackend.WriteSecret(ctx, ownedPath, map[string]any{"username": "admin", "password": "other"}, ownedBy("other-secret"))).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+			mockBackendFactory.AdoptPolicy = secretbackend.AdoptPolicyAdopt
+
+			backendPath := syncedPath(reconcileOwned())
+
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+			Expect(backendPath.SyncStatus).To(Equal("Conflict"))
+			Expect(backendPath.ErrorMessage).To(ContainSubstring("other-secret"))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("OwnershipConflict")))
+		})
+
+		It("Should report a conflict for a secret owned by another cluster", func() {
+			mockBackendFactory.ClusterID = "cluster-a"
+			Expect(mockBackend.WriteSecret(ctx, ownedPath, map[string]any{"username": "admin", "password": "other"},
+				secretbackend.WriteOptions{Metadata: secretbackend.Owner{ClusterID: "cluster-b", BMCSecret: "owned-secret"}.Metadata()})).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+
+			backendPath := syncedPath(reconcileOwned())
+
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+			Expect(backendPath.SyncStatus).To(Equal("Conflict"))
+		})
+
+		It("Should refuse to overwrite a secret without a marker by default", func() {
+			Expect(mockBackend.WriteSecret(ctx, ownedPath, map[string]any{"username": "admin", "password": "manual"}, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+
+			backendPath := syncedPath(reconcileOwned())
+
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+			Expect(backendPath.SyncStatus).To(Equal("Conflict"))
+		})
+
+		It("Should adopt a secret without a marker with the Adopt policy", func() {
+			Expect(mockBackend.WriteSecret(ctx, ownedPath, map[string]any{"username": "admin", "password": "manual"}, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+			mockBackendFactory.AdoptPolicy = secretbackend.AdoptPolicyAdopt
+
+			backendPath := syncedPath(reconcileOwned())
+
+			Expect(backendPath.SyncStatus).To(Equal("Success"))
+			secret, err := mockBackend.ReadSecret(ctx, ownedPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data["password"]).To(Equal("secret123"))
+			Expect(secret.Metadata).To(Equal(ownedBy("owned-secret").Metadata))
+		})
+
+		It("Should add the marker to an up to date secret without one", func() {
+			mockBackendFactory.AdoptPolicy = secretbackend.AdoptPolicyAdopt
+			Expect(mockBackend.WriteSecret(ctx, ownedPath, map[string]any{"username": "admin", "password": "secret123"}, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+
+			reconcileOwned()
+
+			Expect(mockBackend.WriteSecretCalls).To(ConsistOf(HaveField("Metadata", ownedBy("owned-secret").Metadata)))
+		})
+
+		It("Should not rewrite an up to date secret written with another config", func() {
+			Expect(mockBackend.WriteSecret(ctx, ownedPath, map[string]any{"username": "admin", "password": "secret123"},
+				secretbackend.WriteOptions{Metadata: secretbackend.Owner{BMCSecret: "owned-secret", Config: "vault-eu"}.Metadata()})).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+
+			backendPath := syncedPath(reconcileOwned())
+
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+			Expect(backendPath.SyncStatus).To(Equal("Success"))
+		})
+
+		It("Should not delete a secret owned by another BMCSecret", func() {
+			Expect(mockBackend.WriteSecret(ctx, ownedPath, map[string]any{"username": "admin", "password": "other"}, ownedBy("other-secret"))).Error().NotTo(HaveOccurred())
+			now := metav1.Now()
+			bmcSecret.DeletionTimestamp = &now
+
+			reconcileOwned()
+
+			Expect(mockBackend.DeleteSecretCalls).To(BeEmpty())
+			Expect(mockBackend.GetSecretCount()).To(Equal(1))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("OwnershipConflict")))
+		})
+	})
+
+	Context("When backend paths collide", func() {
+		var bmcSecret *metalv1alpha1.BMCSecret
+
+		BeforeEach(func() {
+			bmcSecret = &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "colliding-secret",
+					Finalizers: []string{bmcSecretFinalizer},
+				},
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("secret123"),
+				},
+			}
+		})
+
+		newBMC := func(name, hostname string) *metalv1alpha1.BMC {
+			return &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{"region": "us-east-1"},
+				},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "colliding-secret"},
+					Hostname:     &hostname,
+				},
+			}
+		}
+
+		reconcileColliding := func(objs ...client.Object) []configv1alpha1.BackendPath {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(append(objs, bmcSecret)...).
+				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
+				Build()
+
+			reconciler = &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: mockBackendFactory,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "colliding-secret"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			syncStatus := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "colliding-secret-sync-status"}, syncStatus)).To(Succeed())
+			return syncStatus.Status.BackendPaths
+		}
+
+		It("Should fail the paths of BMCs rendering the same path", func() {
+			backendPaths := reconcileColliding(
+				newBMC("bmc-a", "bmc1.example.com"),
+				newBMC("bmc-b", "bmc1.example.com"),
+				newBMC("bmc-c", "bmc2.example.com"),
+			)
+
+			Expect(mockBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", "bmc/us-east-1/bmc2.example.com/admin")))
+			Expect(backendPaths).To(HaveLen(3))
+			for _, backendPath := range backendPaths[:2] {
+				Expect(backendPath.Path).To(Equal("bmc/us-east-1/bmc1.example.com/admin"))
+				Expect(backendPath.SyncStatus).To(Equal("Failed"))
+				Expect(backendPath.ErrorMessage).To(Equal("path collision: BMCs bmc-a, bmc-b render the same path"))
+			}
+			Expect(backendPaths[2].SyncStatus).To(Equal("Success"))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("PathCollision")))
+		})
+
+		It("Should fail paths already synced for another BMCSecret", func() {
+			otherStatus := &configv1alpha1.BMCSecretSyncStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-secret-sync-status"},
+				Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "other-secret"},
+				Status: configv1alpha1.BMCSecretSyncStatusStatus{
+					BackendPaths: []configv1alpha1.BackendPath{{
+						Path:       "bmc/us-east-1/bmc1.example.com/admin",
+						BMCName:    "other-bmc",
+						SyncStatus: "Success",
+					}},
+				},
+			}
+
+			backendPaths := reconcileColliding(otherStatus, newBMC("bmc-a", "bmc1.example.com"), newBMC("bmc-b", "bmc2.example.com"))
+
+			Expect(mockBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", "bmc/us-east-1/bmc2.example.com/admin")))
+			Expect(backendPaths[0].SyncStatus).To(Equal("Failed"))
+			Expect(backendPaths[0].ErrorMessage).To(Equal("path collision: the path is already synced for BMCSecret other-secret"))
+			Expect(backendPaths[1].SyncStatus).To(Equal("Success"))
+		})
+
+		It("Should keep syncing paths that other BMCSecrets failed to sync", func() {
+			otherStatus := &configv1alpha1.BMCSecretSyncStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-secret-sync-status"},
+				Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "other-secret"},
+				Status: configv1alpha1.BMCSecretSyncStatusStatus{
+					BackendPaths: []configv1alpha1.BackendPath{{
+						Path:       "bmc/us-east-1/bmc1.example.com/admin",
+						BMCName:    "other-bmc",
+						SyncStatus: "Failed",
+					}},
+				},
+			}
+
+			backendPaths := reconcileColliding(otherStatus, newBMC("bmc-a", "bmc1.example.com"))
+
+			Expect(mockBackend.WriteSecretCalls).To(HaveLen(1))
+			Expect(backendPaths[0].SyncStatus).To(Equal("Success"))
+		})
+
+		It("Should fail paths that are not valid secret paths", func() {
+			bmc := newBMC("bmc-a", "bmc1.example.com")
+			bmc.Labels["region"] = ".."
+
+			backendPaths := reconcileColliding(bmc)
+
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+			Expect(backendPaths[0].SyncStatus).To(Equal("Failed"))
+			Expect(backendPaths[0].ErrorMessage).To(ContainSubstring(`invalid secret path "bmc/../bmc1.example.com/admin"`))
+		})
+	})
+
+	Context("When a content hash was recorded for the path", func() {
+		const hashedPath = "bmc/us-east-1/bmc-server1.example.com/admin"
+
+		var desired map[string]any
+
+		BeforeEach(func() {
+			desired = map[string]any{"username": "admin", "password": "secret123"}
+			Expect(mockBackend.WriteSecret(ctx, hashedPath, desired, ownedBy("hashed-secret"))).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+		})
+
+		recordedStatus := func(data map[string]any, verifiedAgo time.Duration) *configv1alpha1.BMCSecretSyncStatus {
+			hash, err := secretbackend.ContentHash(mockBackendFactory.ContentHashKey, data)
+			Expect(err).NotTo(HaveOccurred())
+			verified := metav1.NewTime(time.Now().Add(-verifiedAgo))
+
+			return &configv1alpha1.BMCSecretSyncStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "hashed-secret-sync-status"},
+				Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "hashed-secret"},
+				Status: configv1alpha1.BMCSecretSyncStatusStatus{
+					BackendPaths: []configv1alpha1.BackendPath{{
+						Path:             hashedPath,
+						BMCName:          "test-bmc",
+						SyncStatus:       "Success",
+						ContentHash:      hash,
+						LastVerifiedTime: &verified,
+					}},
+				},
+			}
+		}
+
+		reconcileHashed := func(password string, objs ...client.Object) configv1alpha1.BackendPath {
+			bmcSecret := &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "hashed-secret",
+					Finalizers: []string{bmcSecretFinalizer},
+				},
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte(password),
+				},
+			}
+
+			hostname := testBMCHostname
+			bmc := &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-bmc",
+					Labels: map[string]string{"region": "us-east-1"},
+				},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "hashed-secret"},
+					Hostname:     &hostname,
+				},
+			}
+
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(append(objs, bmcSecret, bmc)...).
+				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
+				Build()
+
+			reconciler = &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: mockBackendFactory,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "hashed-secret"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			syncStatus := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "hashed-secret-sync-status"}, syncStatus)).To(Succeed())
+			Expect(syncStatus.Status.BackendPaths).To(HaveLen(1))
+			return syncStatus.Status.BackendPaths[0]
+		}
+
+		It("Should record the hash and verification time on the first sync", func() {
+			backendPath := reconcileHashed("secret123")
+
+			Expect(backendPath.ContentHash).NotTo(BeEmpty())
+			Expect(backendPath.ContentHash).NotTo(ContainSubstring("secret123"))
+			Expect(secretbackend.ContentHashMatches(mockBackendFactory.ContentHashKey, backendPath.ContentHash, desired)).To(BeTrue())
+			Expect(backendPath.LastVerifiedTime).NotTo(BeNil())
+		})
+
+		It("Should neither read nor write the secret when the hash matches", func() {
+			syncStatus := recordedStatus(desired, time.Hour)
+
+			backendPath := reconcileHashed("secret123", syncStatus)
+
+			Expect(mockBackend.ReadSecretCalls).To(BeEmpty())
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+			Expect(backendPath.ContentHash).To(Equal(syncStatus.Status.BackendPaths[0].ContentHash))
+			Expect(backendPath.LastVerifiedTime.Unix()).To(Equal(syncStatus.Status.BackendPaths[0].LastVerifiedTime.Unix()))
+		})
+
+		It("Should read the secret back when the hash was recorded with another key", func() {
+			syncStatus := recordedStatus(desired, time.Hour)
+			mockBackendFactory.ContentHashKey = []byte("rotated-key")
+
+			backendPath := reconcileHashed("secret123", syncStatus)
+
+			Expect(mockBackend.ReadSecretCalls).To(ConsistOf(hashedPath))
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+			Expect(secretbackend.ContentHashMatches([]byte("rotated-key"), backendPath.ContentHash, desired)).To(BeTrue())
+		})
+
+		It("Should read the secret back when the verification is due", func() {
+			syncStatus := recordedStatus(desired, 25*time.Hour)
+
+			backendPath := reconcileHashed("secret123", syncStatus)
+
+			Expect(mockBackend.ReadSecretCalls).To(ConsistOf(hashedPath))
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+			Expect(backendPath.LastVerifiedTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("Should repair a secret changed in the backend when the verification is due", func() {
+			syncStatus := recordedStatus(desired, 25*time.Hour)
+			Expect(mockBackend.WriteSecret(ctx, hashedPath, map[string]any{"username": "admin", "password": "tampered"}, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+
+			reconcileHashed("secret123", syncStatus)
+
+			Expect(mockBackend.WriteSecretCalls).To(ConsistOf(HaveField("Data", desired)))
+		})
+
+		It("Should write the secret when the hash differs", func() {
+			syncStatus := recordedStatus(desired, time.Hour)
+
+			backendPath := reconcileHashed("rotated", syncStatus)
+
+			Expect(mockBackend.WriteSecretCalls).To(HaveLen(1))
+			Expect(secretbackend.ContentHashMatches(mockBackendFactory.ContentHashKey, backendPath.ContentHash, map[string]any{"username": "admin", "password": "rotated"})).To(BeTrue())
+		})
+
+		It("Should always read the secret back with a zero verification interval", func() {
+			mockBackendFactory.VerifyInterval = 0
+			syncStatus := recordedStatus(desired, time.Second)
+
+			reconcileHashed("secret123", syncStatus)
+
+			Expect(mockBackend.ReadSecretCalls).To(ConsistOf(hashedPath))
+		})
+
+		It("Should record the version read back when the secret is up to date", func() {
+			syncStatus := recordedStatus(desired, 25*time.Hour)
+
+			backendPath := reconcileHashed("secret123", syncStatus)
+
+			Expect(backendPath.Version).To(Equal(1))
+			Expect(backendPath.VersionCreatedTime).NotTo(BeNil())
+		})
+
+		It("Should record the version written when the hash differs", func() {
+			syncStatus := recordedStatus(desired, time.Hour)
+
+			backendPath := reconcileHashed("rotated", syncStatus)
+
+			Expect(backendPath.Version).To(Equal(2))
+			Expect(backendPath.VersionCreatedTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("Should keep the recorded version when the hash matches", func() {
+			syncStatus := recordedStatus(desired, time.Hour)
+			created := metav1.NewTime(time.Now().Add(-48 * time.Hour))
+			syncStatus.Status.BackendPaths[0].Version = 7
+			syncStatus.Status.BackendPaths[0].VersionCreatedTime = &created
+
+			backendPath := reconcileHashed("secret123", syncStatus)
+
+			Expect(backendPath.Version).To(Equal(7))
+			Expect(backendPath.VersionCreatedTime.Unix()).To(Equal(created.Unix()))
+		})
+	})
+
+	Context("When syncing many BMCs", func() {
+		newBMCs := func(count int) []client.Object {
+			objs := make([]client.Object, 0, count)
+			// Create in reverse order to verify that results are sorted
+			for i := count; i > 0; i-- {
+				hostname := fmt.Sprintf("bmc%02d.example.com", i)
+				objs = append(objs, &metalv1alpha1.BMC{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   fmt.Sprintf("bmc-%02d", i),
+						Labels: map[string]string{"region": "us-east-1"},
+					},
+					Spec: metalv1alpha1.BMCSpec{
+						BMCSecretRef: corev1.LocalObjectReference{Name: "shared-secret"},
+						Hostname:     &hostname,
+					},
+				})
+			}
+			return objs
+		}
+
+		reconcileShared := func(factory secretbackend.BackendFactoryInterface, bmcs []client.Object, secretLabels map[string]string) *configv1alpha1.BMCSecretSyncStatus {
+			bmcSecret := &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "shared-secret",
+					Labels:     secretLabels,
+					Finalizers: []string{bmcSecretFinalizer},
+				},
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("secret123"),
+				},
+			}
+
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(append(bmcs, bmcSecret)...).
+				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
+				Build()
+
+			reconciler = &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: factory,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "shared-secret"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			syncStatus := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "shared-secret-sync-status"}, syncStatus)).To(Succeed())
+			return syncStatus
+		}
+
+		It("Should sync paths in parallel up to the configured limit", func() {
+			mockBackend.WriteDelay = 20 * time.Millisecond
+			mockBackendFactory.MaxConcurrency = 4
+
+			syncStatus := reconcileShared(mockBackendFactory, newBMCs(12), nil)
+
+			Expect(mockBackend.GetWriteCallCount()).To(Equal(12))
+			Expect(mockBackend.GetMaxConcurrentWrites()).To(BeNumerically(">", 1))
+			Expect(mockBackend.GetMaxConcurrentWrites()).To(BeNumerically("<=", 4))
+			Expect(syncStatus.Status.SuccessfulPaths).To(Equal(12))
+		})
+
+		It("Should sync paths one at a time with a limit of one", func() {
+			mockBackend.WriteDelay = 5 * time.Millisecond
+			mockBackendFactory.MaxConcurrency = 1
+
+			reconcileShared(mockBackendFactory, newBMCs(5), nil)
+
+			Expect(mockBackend.GetWriteCallCount()).To(Equal(5))
+			Expect(mockBackend.GetMaxConcurrentWrites()).To(Equal(1))
+		})
+
+		It("Should record backend paths ordered by engine and BMC name", func() {
+			teamBConcurrency := int32(2)
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory([]configv1alpha1.SecretEngineConfig{
+				{Name: "team-a", MountPath: "team-a", PathTemplate: "a/{{.Hostname}}", SyncLabel: "sync"},
+				{Name: "team-b", MountPath: "team-b", PathTemplate: "b/{{.Hostname}}", SyncLabel: "sync", MaxConcurrentSyncs: &teamBConcurrency},
+			}, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+			multiEngineFactory.GetMockBackendForEngine("team-b").WriteDelay = 5 * time.Millisecond
+
+			syncStatus := reconcileShared(multiEngineFactory, newBMCs(6), map[string]string{"sync": "true"})
+
+			var keys []string
+			for _, backendPath := range syncStatus.Status.BackendPaths {
+				keys = append(keys, backendPath.Engine+"/"+backendPath.BMCName)
+			}
+			Expect(keys).To(Equal([]string{
+				"team-a/bmc-01", "team-a/bmc-02", "team-a/bmc-03", "team-a/bmc-04", "team-a/bmc-05", "team-a/bmc-06",
+				"team-b/bmc-01", "team-b/bmc-02", "team-b/bmc-03", "team-b/bmc-04", "team-b/bmc-05", "team-b/bmc-06",
+			}))
+			Expect(multiEngineFactory.GetMockBackendForEngine("team-b").GetMaxConcurrentWrites()).To(BeNumerically("<=", 2))
+		})
+
+		It("Should record the namespace of the backend paths", func() {
+			mockBackendFactory.Location.Namespace = "admin/bmc"
+
+			syncStatus := reconcileShared(mockBackendFactory, newBMCs(2), nil)
+
+			Expect(syncStatus.Status.BackendPaths).To(HaveLen(2))
+			for _, backendPath := range syncStatus.Status.BackendPaths {
+				Expect(backendPath.Namespace).To(Equal("admin/bmc"))
+			}
+		})
+
+		It("Should record the namespace overridden by an engine", func() {
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory([]configv1alpha1.SecretEngineConfig{
+				{Name: "team-a", MountPath: "team-a", PathTemplate: "a/{{.Hostname}}", SyncLabel: "sync"},
+				{Name: "team-b", MountPath: "team-b", PathTemplate: "b/{{.Hostname}}", SyncLabel: "sync", Namespace: "admin/team-b"},
+			}, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+			multiEngineFactory.Location.Namespace = "admin"
+
+			syncStatus := reconcileShared(multiEngineFactory, newBMCs(1), map[string]string{"sync": "true"})
+
+			namespaces := map[string]string{}
+			for _, backendPath := range syncStatus.Status.BackendPaths {
+				namespaces[backendPath.Engine] = backendPath.Namespace
+			}
+			Expect(namespaces).To(Equal(map[string]string{"team-a": "admin", "team-b": "admin/team-b"}))
+		})
+	})
+
+	Context("When previously synced paths are no longer desired", func() {
+		const (
+			oldPath = "bmc/us-east-1/old-host.example.com/admin"
+			newPath = "bmc/us-east-1/bmc-server1.example.com/admin"
+		)
+
+		var (
+			bmcSecret  *metalv1alpha1.BMCSecret
+			bmc        *metalv1alpha1.BMC
+			syncStatus *configv1alpha1.BMCSecretSyncStatus
+		)
+
+		BeforeEach(func() {
+			bmcSecret = &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "moved-secret",
+					Finalizers: []string{bmcSecretFinalizer},
+				},
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("secret123"),
+				},
+			}
+
+			hostname := testBMCHostname
+			bmc = &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-bmc",
+					Labels: map[string]string{"region": "us-east-1"},
+				},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "moved-secret"},
+					Hostname:     &hostname,
+				},
+			}
+
+			syncStatus = &configv1alpha1.BMCSecretSyncStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "moved-secret-sync-status"},
+				Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "moved-secret"},
+				Status: configv1alpha1.BMCSecretSyncStatusStatus{
+					BackendPaths: []configv1alpha1.BackendPath{
+						{Path: oldPath, BMCName: "test-bmc", SyncStatus: "Success"},
+					},
+				},
+			}
+
+			// Written before ownership markers were introduced
+			Expect(mockBackend.WriteSecret(ctx, oldPath, map[string]any{"username": "admin", "password": "secret123"}, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+		})
+
+		reconcileMoved := func(objs ...client.Object) *configv1alpha1.BMCSecretSyncStatus {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(objs...).
+				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
+				Build()
+
+			reconciler = &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: mockBackendFactory,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "moved-secret"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "moved-secret-sync-status"}, updated)).To(Succeed())
+			return updated
+		}
+
+		It("Should delete the old path when the BMC hostname changes", func() {
+			updated := reconcileMoved(bmcSecret, bmc, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(ConsistOf(oldPath))
+			Expect(mockBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", newPath)))
+			Expect(updated.Status.BackendPaths).To(ConsistOf(HaveField("Path", newPath)))
+			Expect(updated.Status.OrphanedPaths).To(BeEmpty())
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("OrphanPruned")))
+		})
+
+		It("Should keep and report the old path with the Report policy", func() {
+			mockBackendFactory.OrphanPolicy = secretbackend.OrphanPolicyReport
+
+			updated := reconcileMoved(bmcSecret, bmc, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(BeEmpty())
+			Expect(updated.Status.OrphanedPaths).To(ConsistOf(HaveField("Path", oldPath)))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("OrphanDetected")))
+		})
+
+		It("Should keep the old path silently with the Retain policy", func() {
+			mockBackendFactory.OrphanPolicy = secretbackend.OrphanPolicyRetain
+
+			updated := reconcileMoved(bmcSecret, bmc, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(BeEmpty())
+			Expect(updated.Status.OrphanedPaths).To(BeEmpty())
+		})
+
+		It("Should keep the path as orphaned when deleting it fails", func() {
+			mockBackend.DeleteError = fmt.Errorf("permission denied")
+
+			updated := reconcileMoved(bmcSecret, bmc, syncStatus)
+
+			Expect(updated.Status.OrphanedPaths).To(ConsistOf(HaveField("Path", oldPath)))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("OrphanPruneFailed")))
+		})
+
+		It("Should delete all paths when no BMC references the secret anymore", func() {
+			updated := reconcileMoved(bmcSecret, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(ConsistOf(oldPath))
+			Expect(updated.Status.BackendPaths).To(BeEmpty())
+		})
+
+		It("Should only remove the path of a BMC that switched to another secret", func() {
+			otherHostname := "bmc-server2.example.com"
+			otherBMC := &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "switched-bmc",
+					Labels: map[string]string{"region": "us-east-1"},
+				},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "other-secret"},
+					Hostname:     &otherHostname,
+				},
+			}
+			switchedPath := "bmc/us-east-1/bmc-server2.example.com/admin"
+			syncStatus.Status.BackendPaths = []configv1alpha1.BackendPath{
+				{Path: newPath, BMCName: "test-bmc", SyncStatus: "Success"},
+				{Path: switchedPath, BMCName: "switched-bmc", SyncStatus: "Success"},
+			}
+
+			updated := reconcileMoved(bmcSecret, bmc, otherBMC, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(ConsistOf(switchedPath))
+			Expect(updated.Status.BackendPaths).To(ConsistOf(HaveField("BMCName", "test-bmc")))
+		})
+
+		It("Should keep the previous path of a BMC whose path can no longer be built", func() {
+			otherHostname := "bmc-server2.example.com"
+			otherBMC := &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "other-bmc",
+					Labels: map[string]string{"region": "us-east-1"},
+				},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "moved-secret"},
+					Hostname:     &otherHostname,
+				},
+			}
+			otherPath := "bmc/us-east-1/bmc-server2.example.com/admin"
+			// The hostname of test-bmc renders an invalid path segment
+			invalidHostname := "bmc server1"
+			bmc.Spec.Hostname = &invalidHostname
+			syncStatus.Status.BackendPaths = []configv1alpha1.BackendPath{
+				{Path: newPath, BMCName: "test-bmc", SyncStatus: "Success"},
+			}
+
+			updated := reconcileMoved(bmcSecret, bmc, otherBMC, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(BeEmpty())
+			Expect(mockBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", otherPath)))
+			Expect(updated.Status.BackendPaths).To(ConsistOf(
+				SatisfyAll(
+					HaveField("Path", newPath),
+					HaveField("BMCName", "test-bmc"),
+					HaveField("SyncStatus", "Failed"),
+					HaveField("ErrorMessage", ContainSubstring("invalid secret path")),
+				),
+				SatisfyAll(HaveField("Path", otherPath), HaveField("SyncStatus", "Success")),
+			))
+			Expect(updated.Status.OrphanedPaths).To(BeEmpty())
+		})
+
+		It("Should count the paths recorded rather than the BMCs in the totals", func() {
+			otherHostname := "bmc-server2.example.com"
+			otherBMC := &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "other-bmc",
+					Labels: map[string]string{"region": "us-east-1"},
+				},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "moved-secret"},
+					Hostname:     &otherHostname,
+				},
+			}
+			invalidHostname := "bmc server1"
+			bmc.Spec.Hostname = &invalidHostname
+			// Both paths of test-bmc are kept as its path can no longer be built
+			syncStatus.Status.BackendPaths = []configv1alpha1.BackendPath{
+				{Path: newPath, BMCName: "test-bmc", SyncStatus: "Success"},
+				{Path: "bmc/us-west-1/bmc-server1.example.com/admin", BMCName: "test-bmc", SyncStatus: "Success"},
+			}
+
+			updated := reconcileMoved(bmcSecret, bmc, otherBMC, syncStatus)
+
+			Expect(updated.Status.BackendPaths).To(HaveLen(3))
+			Expect(updated.Status.TotalPaths).To(Equal(3))
+			Expect(updated.Status.SuccessfulPaths).To(Equal(1))
+			Expect(updated.Status.FailedPaths).To(Equal(2))
+		})
+
+		It("Should write to the new mount and delete the path at the old one when the mount path changes", func() {
+			oldLocation := secretbackend.Location{Address: "https://vault.example.com", MountPath: "secret"}
+			data := map[string]any{"username": "admin", "password": "secret123"}
+			Expect(mockBackend.WriteSecret(ctx, newPath, data, ownedBy("moved-secret"))).Error().NotTo(HaveOccurred())
+			hash, err := secretbackend.ContentHash(mockBackendFactory.ContentHashKey, data)
+			Expect(err).NotTo(HaveOccurred())
+			verified := metav1.Now()
+			syncStatus.Status.BackendPaths = []configv1alpha1.BackendPath{{
+				Path: newPath, BMCName: "test-bmc", SyncStatus: "Success",
+				Address: oldLocation.Address, MountPath: oldLocation.MountPath,
+				ContentHash: hash, LastVerifiedTime: &verified,
+			}}
+
+			newBackend := mock.NewMockBackend()
+			mockBackendFactory.Backend = newBackend
+			mockBackendFactory.Location = secretbackend.Location{Address: oldLocation.Address, MountPath: "kv"}
+			mockBackendFactory.LocationBackends = map[secretbackend.Location]*mock.MockBackend{oldLocation: mockBackend}
+
+			updated := reconcileMoved(bmcSecret, bmc, syncStatus)
+
+			Expect(newBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", newPath)))
+			Expect(mockBackend.DeleteSecretCalls).To(ConsistOf(newPath))
+			Expect(updated.Status.BackendPaths).To(ConsistOf(And(HaveField("Path", newPath), HaveField("MountPath", "kv"))))
+			Expect(updated.Status.OrphanedPaths).To(BeEmpty())
+		})
+
+		It("Should keep paths recorded before their location at the current location", func() {
+			syncStatus.Status.BackendPaths = []configv1alpha1.BackendPath{
+				{Path: newPath, BMCName: "test-bmc", SyncStatus: "Success"},
+			}
+			mockBackendFactory.Location = secretbackend.Location{Address: "https://vault.example.com", MountPath: "secret"}
+
+			updated := reconcileMoved(bmcSecret, bmc, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(BeEmpty())
+			Expect(updated.Status.BackendPaths).To(ConsistOf(And(HaveField("Path", newPath), HaveField("MountPath", "secret"))))
+			Expect(updated.Status.OrphanedPaths).To(BeEmpty())
+		})
+
+		It("Should soft delete the old path with the SoftDelete deletion policy", func() {
+			mockBackendFactory.DeletionPolicy = secretbackend.DeletionPolicySoftDelete
+
+			updated := reconcileMoved(bmcSecret, bmc, syncStatus)
+
+			Expect(mockBackend.SoftDeleteCalls).To(ConsistOf(oldPath))
+			Expect(updated.Status.OrphanedPaths).To(BeEmpty())
+		})
+
+		It("Should release the old path without deleting it with the Retain deletion policy", func() {
+			mockBackendFactory.DeletionPolicy = secretbackend.DeletionPolicyRetain
+
+			updated := reconcileMoved(bmcSecret, bmc, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(BeEmpty())
+			Expect(mockBackend.ReadSecret(ctx, oldPath)).Error().NotTo(HaveOccurred())
+			Expect(updated.Status.OrphanedPaths).To(BeEmpty())
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("OrphanRetained")))
+		})
+
+		It("Should apply the deletion policy when the BMCSecret is deleted", func() {
+			mockBackendFactory.DeletionPolicy = secretbackend.DeletionPolicyRetain
+			now := metav1.Now()
+			bmcSecret.DeletionTimestamp = &now
+
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(bmcSecret, bmc, syncStatus).
+				Build()
+			reconciler = &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: mockBackendFactory,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "moved-secret"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockBackend.DeleteSecretCalls).To(BeEmpty())
+			Expect(mockBackend.ReadSecret(ctx, oldPath)).Error().NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: "moved-secret-sync-status"}, &configv1alpha1.BMCSecretSyncStatus{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When a BMCSecret is not in the sync scope", func() {
+		const syncedPath = "bmc/us-east-1/bmc-server1.example.com/admin"
+
+		var (
+			bmcSecret  *metalv1alpha1.BMCSecret
+			syncStatus *configv1alpha1.BMCSecretSyncStatus
+		)
+
+		BeforeEach(func() {
+			mockBackendFactory.SyncLabel = "sync-enabled"
+
+			// Synced before the label was removed
+			bmcSecret = &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "unlabeled-secret",
+					Finalizers: []string{bmcSecretFinalizer},
+				},
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("secret123"),
+				},
+			}
+			syncStatus = &configv1alpha1.BMCSecretSyncStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "unlabeled-secret-sync-status"},
+				Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "unlabeled-secret"},
+				Status: configv1alpha1.BMCSecretSyncStatusStatus{
+					BackendPaths: []configv1alpha1.BackendPath{
+						{Path: syncedPath, BMCName: "test-bmc", SyncStatus: "Success"},
+					},
+				},
+			}
+
+			Expect(mockBackend.WriteSecret(ctx, syncedPath, map[string]any{"username": "admin", "password": "secret123"}, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+			mockBackend.WriteSecretCalls = nil
+		})
+
+		reconcileUnlabeled := func(objs ...client.Object) client.Client {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(objs...).
+				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
+				Build()
+
+			reconciler = &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: mockBackendFactory,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "unlabeled-secret"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return k8sClient
+		}
+
+		It("Should release the paths synced before it lost the sync label", func() {
+			k8sClient := reconcileUnlabeled(bmcSecret, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(ConsistOf(syncedPath))
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("OutOfScope")))
+
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: "unlabeled-secret-sync-status"}, &configv1alpha1.BMCSecretSyncStatus{})
+			Expect(err).To(HaveOccurred())
+
+			updated := &metalv1alpha1.BMCSecret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "unlabeled-secret"}, updated)).To(Succeed())
+			Expect(updated.Finalizers).NotTo(ContainElement(bmcSecretFinalizer))
+		})
+
+		It("Should keep the finalizer while reported orphans remain", func() {
+			mockBackendFactory.OrphanPolicy = secretbackend.OrphanPolicyReport
+
+			k8sClient := reconcileUnlabeled(bmcSecret, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(BeEmpty())
+			updatedStatus := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "unlabeled-secret-sync-status"}, updatedStatus)).To(Succeed())
+			Expect(updatedStatus.Status.BackendPaths).To(BeEmpty())
+			Expect(updatedStatus.Status.OrphanedPaths).To(ConsistOf(HaveField("Path", syncedPath)))
+
+			updated := &metalv1alpha1.BMCSecret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "unlabeled-secret"}, updated)).To(Succeed())
+			Expect(updated.Finalizers).To(ContainElement(bmcSecretFinalizer))
+		})
+
+		It("Should clean up on deletion after it lost the sync label", func() {
+			now := metav1.Now()
+			bmcSecret.DeletionTimestamp = &now
+
+			reconcileUnlabeled(bmcSecret, syncStatus)
+
+			Expect(mockBackend.DeleteSecretCalls).To(ConsistOf(syncedPath))
+		})
+
+		It("Should filter events with the sync selector configured at event time", func() {
+			reconciler = &BMCSecretReconciler{BackendFactory: mockBackendFactory}
+			scopePredicate := reconciler.syncScopePredicate()
+
+			unlabeled := &metalv1alpha1.BMCSecret{ObjectMeta: metav1.ObjectMeta{Name: "new-secret"}}
+			Expect(scopePredicate.Create(event.CreateEvent{Object: unlabeled})).To(BeFalse())
+
+			mockBackendFactory.SyncLabel = ""
+			Expect(scopePredicate.Create(event.CreateEvent{Object: unlabeled})).To(BeTrue())
+
+			mockBackendFactory.SyncLabel = "team in (a,b),env!=lab"
+			Expect(scopePredicate.Create(event.CreateEvent{Object: &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-secret", Labels: map[string]string{"team": "a"}},
+			}})).To(BeTrue())
+			Expect(scopePredicate.Create(event.CreateEvent{Object: &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "lab-secret", Labels: map[string]string{"team": "a", "env": "lab"}},
+			}})).To(BeFalse())
+
+			// Secrets synced before must see the label removal to be released
+			mockBackendFactory.SyncLabel = "sync-enabled"
+			Expect(scopePredicate.Update(event.UpdateEvent{ObjectOld: bmcSecret, ObjectNew: bmcSecret})).To(BeTrue())
+		})
+
+		It("Should enqueue all BMCSecrets on configuration changes", func() {
+			reconcileUnlabeled(bmcSecret, &metalv1alpha1.BMCSecret{ObjectMeta: metav1.ObjectMeta{Name: "other-secret"}})
+
+			requests := reconciler.findAllBMCSecrets(ctx, &configv1alpha1.SecretBackendConfig{})
+			Expect(requests).To(ConsistOf(
+				HaveField("Name", "unlabeled-secret"),
+				HaveField("Name", "other-secret"),
+			))
+		})
+	})
+
+	Context("When syncing with multiple backend configurations", func() {
+		const (
+			defaultPath = "bmc/us-east-1/bmc-server1.example.com/admin"
+			euPath      = "eu/bmc-server1.example.com/admin"
+		)
+
+		var (
+			euBackend *mock.MockBackend
+			registry  *mock.MockBackendRegistry
+			bmc       *metalv1alpha1.BMC
+		)
+
+		BeforeEach(func() {
+			mockBackendFactory.SyncLabel = "env=prod"
+
+			euBackend = mock.NewMockBackend()
+			euFactory, err := mock.NewMockBackendFactory(euBackend, "eu/{{.Hostname}}/{{.Username}}", "region", "team=eu")
+			Expect(err).NotTo(HaveOccurred())
+			euFactory.ConfigName = "vault-eu"
+
+			registry = &mock.MockBackendRegistry{
+				Factories: map[string]secretbackend.BackendFactoryInterface{
+					secretbackend.DefaultBackendConfigName: mockBackendFactory,
+					"vault-eu":                             euFactory,
+				},
+			}
+
+			hostname := testBMCHostname
+			bmc = &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-bmc",
+					Labels: map[string]string{"region": "us-east-1"},
+				},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "multi-secret"},
+					Hostname:     &hostname,
+				},
+			}
+		})
+
+		newBMCSecret := func(labels map[string]string, finalizers ...string) *metalv1alpha1.BMCSecret {
+			return &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "multi-secret",
+					Labels:     labels,
+					Finalizers: finalizers,
+				},
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("secret123"),
+				},
+			}
+		}
+
+		reconcileMulti := func(objs ...client.Object) client.Client {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(objs...).
+				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
+				Build()
+
+			reconciler = &BMCSecretReconciler{
+				Client:   k8sClient,
+				Scheme:   scheme,
+				Recorder: recorder,
+				Registry: registry,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "multi-secret"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return k8sClient
+		}
+
+		It("Should sync to every matching configuration with a status each", func() {
+			k8sClient := reconcileMulti(newBMCSecret(map[string]string{"env": "prod", "team": "eu"}), bmc)
+
+			Expect(mockBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", defaultPath)))
+			Expect(euBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", euPath)))
+			Expect(euBackend.WriteSecretCalls[0].Metadata).To(Equal(secretbackend.Owner{BMCSecret: "multi-secret", Config: "vault-eu"}.Metadata()))
+
+			defaultStatus := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "multi-secret-sync-status"}, defaultStatus)).To(Succeed())
+			Expect(defaultStatus.Spec.ConfigRef).To(Equal(secretbackend.DefaultBackendConfigName))
+			Expect(defaultStatus.Status.BackendPaths).To(ConsistOf(HaveField("Path", defaultPath)))
+
+			var euStatuses configv1alpha1.BMCSecretSyncStatusList
+			Expect(k8sClient.List(ctx, &euStatuses, client.MatchingLabels{
+				syncStatusBMCSecretLabel: "multi-secret",
+				syncStatusConfigLabel:    "vault-eu",
+			})).To(Succeed())
+			Expect(euStatuses.Items).To(HaveLen(1))
+			Expect(euStatuses.Items[0].Name).NotTo(Equal("multi-secret-vault-eu-sync-status"))
+			Expect(euStatuses.Items[0].Spec.ConfigRef).To(Equal("vault-eu"))
+			Expect(euStatuses.Items[0].Status.BackendPaths).To(ConsistOf(HaveField("Path", euPath)))
+		})
+
+		It("Should not mistake the status of another BMCSecret and configuration pair", func() {
+			// Before statuses were labeled, multi-secret-vault with eu had the same name
+			// as multi-secret with vault-eu
+			otherStatus := &configv1alpha1.BMCSecretSyncStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "multi-secret-vault-eu-sync-status"},
+				Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "multi-secret-vault", ConfigRef: "eu"},
+				Status: configv1alpha1.BMCSecretSyncStatusStatus{
+					BackendPaths: []configv1alpha1.BackendPath{
+						{Path: "eu/other-path", BMCName: "other-bmc", SyncStatus: "Success"},
+					},
+				},
+			}
+
+			k8sClient := reconcileMulti(newBMCSecret(map[string]string{"team": "eu"}), bmc, otherStatus)
+
+			Expect(euBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", euPath)))
+			Expect(euBackend.DeleteSecretCalls).To(BeEmpty())
+
+			unchanged := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "multi-secret-vault-eu-sync-status"}, unchanged)).To(Succeed())
+			Expect(unchanged.Spec.BMCSecretRef).To(Equal("multi-secret-vault"))
+			Expect(unchanged.Status.BackendPaths).To(ConsistOf(HaveField("Path", "eu/other-path")))
+
+			var euStatuses configv1alpha1.BMCSecretSyncStatusList
+			Expect(k8sClient.List(ctx, &euStatuses, client.MatchingLabels{syncStatusConfigLabel: "vault-eu"})).To(Succeed())
+			Expect(euStatuses.Items).To(ConsistOf(HaveField("Spec.BMCSecretRef", "multi-secret")))
+		})
+
+		It("Should label a status created before statuses were labeled and record its configuration", func() {
+			legacyStatus := &configv1alpha1.BMCSecretSyncStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "multi-secret-sync-status"},
+				Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "multi-secret"},
+			}
+
+			k8sClient := reconcileMulti(newBMCSecret(map[string]string{"env": "prod"}), bmc, legacyStatus)
+
+			updated := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "multi-secret-sync-status"}, updated)).To(Succeed())
+			Expect(updated.Spec.ConfigRef).To(Equal(secretbackend.DefaultBackendConfigName))
+			Expect(updated.Labels).To(HaveKeyWithValue(syncStatusBMCSecretLabel, "multi-secret"))
+			Expect(updated.Labels).To(HaveKeyWithValue(syncStatusConfigLabel, secretbackend.DefaultBackendConfigName))
+			Expect(updated.Status.BackendPaths).To(ConsistOf(HaveField("Path", defaultPath)))
+		})
+
+		It("Should only sync to configurations whose selector matches", func() {
+			k8sClient := reconcileMulti(newBMCSecret(map[string]string{"team": "eu"}), bmc)
+
+			Expect(mockBackend.WriteSecretCalls).To(BeEmpty())
+			Expect(euBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", euPath)))
+
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: "multi-secret-sync-status"}, &configv1alpha1.BMCSecretSyncStatus{})
+			Expect(err).To(HaveOccurred())
+
+			updated := &metalv1alpha1.BMCSecret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "multi-secret"}, updated)).To(Succeed())
+			Expect(updated.Finalizers).To(ContainElement(bmcSecretFinalizer))
+		})
+
+		It("Should release the paths of a configuration the BMCSecret left", func() {
+			Expect(euBackend.WriteSecret(ctx, euPath, map[string]any{"username": "admin", "password": "secret123"}, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+			euStatus := &configv1alpha1.BMCSecretSyncStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "multi-secret-vault-eu-sync-status"},
+				Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "multi-secret", ConfigRef: "vault-eu"},
+				Status: configv1alpha1.BMCSecretSyncStatusStatus{
+					BackendPaths: []configv1alpha1.BackendPath{
+						{Path: euPath, BMCName: "test-bmc", SyncStatus: "Success"},
+					},
+				},
+			}
+
+			k8sClient := reconcileMulti(newBMCSecret(map[string]string{"env": "prod"}, bmcSecretFinalizer), bmc, euStatus)
+
+			Expect(euBackend.DeleteSecretCalls).To(ConsistOf(euPath))
+			Expect(mockBackend.WriteSecretCalls).To(ConsistOf(HaveField("Path", defaultPath)))
+
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: "multi-secret-vault-eu-sync-status"}, &configv1alpha1.BMCSecretSyncStatus{})
+			Expect(err).To(HaveOccurred())
+
+			updated := &metalv1alpha1.BMCSecret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "multi-secret"}, updated)).To(Succeed())
+			Expect(updated.Finalizers).To(ContainElement(bmcSecretFinalizer))
+		})
+
+		It("Should clean up every configuration on deletion", func() {
+			bmcSecret := newBMCSecret(map[string]string{"env": "prod", "team": "eu"})
+			reconcileMulti(bmcSecret, bmc)
+
+			k8sClient := reconciler.Client
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "multi-secret"}, bmcSecret)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, bmcSecret)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "multi-secret"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockBackend.DeleteSecretCalls).To(ConsistOf(defaultPath))
+			Expect(euBackend.DeleteSecretCalls).To(ConsistOf(euPath))
+
+			var syncStatuses configv1alpha1.BMCSecretSyncStatusList
+			Expect(k8sClient.List(ctx, &syncStatuses)).To(Succeed())
+			Expect(syncStatuses.Items).To(BeEmpty())
+		})
+
+		It("Should admit events matching the selector of any configuration", func() {
+			reconciler = &BMCSecretReconciler{Registry: registry}
+			scopePredicate := reconciler.syncScopePredicate()
+
+			Expect(scopePredicate.Create(event.CreateEvent{Object: newBMCSecret(map[string]string{"team": "eu"})})).To(BeTrue())
+			Expect(scopePredicate.Create(event.CreateEvent{Object: newBMCSecret(map[string]string{"env": "prod"})})).To(BeTrue())
+			Expect(scopePredicate.Create(event.CreateEvent{Object: newBMCSecret(map[string]string{"team": "us"})})).To(BeFalse())
+		})
+	})
+
+	Context("When a BMC changes", func() {
+		newBMC := func(secretName string) *metalv1alpha1.BMC {
+			return &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-bmc"},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: secretName},
+				},
+			}
+		}
+
+		queuedRequests := func(queue workqueue.TypedRateLimitingInterface[reconcile.Request]) []string {
+			var names []string
+			for queue.Len() > 0 {
+				req, _ := queue.Get()
+				names = append(names, req.Name)
+				queue.Done(req)
+			}
+			return names
+		}
+
+		BeforeEach(func() {
+			reconciler = &BMCSecretReconciler{BackendFactory: mockBackendFactory}
+		})
+
+		It("Should enqueue the old and the new secret when the BMC switches secrets", func() {
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			DeferCleanup(queue.ShutDown)
+
+			reconciler.bmcEventHandler().Update(ctx, event.UpdateEvent{
+				ObjectOld: newBMC("old-secret"),
+				ObjectNew: newBMC("new-secret"),
+			}, queue)
+
+			Expect(queuedRequests(queue)).To(ConsistOf("old-secret", "new-secret"))
+		})
+
+		It("Should enqueue the secret once when the reference is unchanged", func() {
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			DeferCleanup(queue.ShutDown)
+
+			reconciler.bmcEventHandler().Update(ctx, event.UpdateEvent{
+				ObjectOld: newBMC("same-secret"),
+				ObjectNew: newBMC("same-secret"),
+			}, queue)
+
+			Expect(queuedRequests(queue)).To(ConsistOf("same-secret"))
+		})
+	})
+})
+
+var _ = Describe("BMCSecret Multi-Engine Controller", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	Context("Single engine label match", func() {
+		It("Should match engine with exact label value", func() {
+			engines := []configv1alpha1.SecretEngineConfig{
+				{
+					Name:         "team-a",
+					MountPath:    "secret",
+					PathTemplate: "bmc/team-a/{{.Region}}/{{.Hostname}}/{{.Username}}",
+					SyncLabel:    "team=a",
+				},
+			}
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+
+			labels := map[string]string{"team": "a"}
+			matchingEngines, err := multiEngineFactory.GetMatchingEngines(ctx, labels)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matchingEngines).To(HaveLen(1))
+			Expect(matchingEngines[0].Name).To(Equal("team-a"))
+		})
+	})
+
+	Context("Multiple engine label matches", func() {
+		It("Should match all matching engines", func() {
+			engines := []configv1alpha1.SecretEngineConfig{
+				{
+					Name:      "team-a",
+					MountPath: "team-a-secret",
+					SyncLabel: "team=a",
+				},
+				{
+					Name:      "critical-prod",
+					MountPath: "critical-secret",
+					SyncLabel: "critical=true",
+				},
+			}
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+
+			labels := map[string]string{"team": "a", "critical": "true"}
+			matchingEngines, err := multiEngineFactory.GetMatchingEngines(ctx, labels)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matchingEngines).To(HaveLen(2))
+		})
+	})
+
+	Context("Label matching - exact vs any value", func() {
+		It("Should match exact label values (key=value)", func() {
+			engines := []configv1alpha1.SecretEngineConfig{
+				{
+					Name:      "exact-match",
+					MountPath: "secret",
+					SyncLabel: "env=prod",
+				},
+			}
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+
+			labels1 := map[string]string{"env": "prod"}
+			matching, err := multiEngineFactory.GetMatchingEngines(ctx, labels1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(HaveLen(1))
+
+			labels2 := map[string]string{"env": "dev"}
+			matching, err = multiEngineFactory.GetMatchingEngines(ctx, labels2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(BeEmpty())
+		})
+
+		It("Should match any value for key-only labels", func() {
+			engines := []configv1alpha1.SecretEngineConfig{
+				{
+					Name:      "any-value-match",
+					MountPath: "secret",
+					SyncLabel: "sync-enabled",
+				},
+			}
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+
+			labels1 := map[string]string{"sync-enabled": "true"}
+			matching, err := multiEngineFactory.GetMatchingEngines(ctx, labels1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(HaveLen(1))
+
+			labels2 := map[string]string{"sync-enabled": "yes"}
+			matching, err = multiEngineFactory.GetMatchingEngines(ctx, labels2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(HaveLen(1))
+
+			labels3 := map[string]string{"other-label": "value"}
+			matching, err = multiEngineFactory.GetMatchingEngines(ctx, labels3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(BeEmpty())
+		})
+
+		It("Should match selector expressions", func() {
+			engines := []configv1alpha1.SecretEngineConfig{
+				{
+					Name:      "teams",
+					MountPath: "secret",
+					SyncSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}},
+							{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"lab"}},
+						},
+					},
+				},
+				{
+					Name:      "legacy",
+					MountPath: "legacy",
+					SyncLabel: "team in (a,b),env!=lab",
+				},
+			}
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+
+			matching, err := multiEngineFactory.GetMatchingEngines(ctx, map[string]string{"team": "b", "env": "prod"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(HaveLen(2))
+
+			matching, err = multiEngineFactory.GetMatchingEngines(ctx, map[string]string{"team": "a", "env": "lab"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(BeEmpty())
+		})
+	})
+
+	Context("Global syncLabel filtering", func() {
+		It("Should require global syncLabel when configured", func() {
+			engines := []configv1alpha1.SecretEngineConfig{
+				{
+					Name:      "team-a",
+					MountPath: "secret",
+					SyncLabel: "team=a",
+				},
+			}
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "globally-sync", "region")
+			Expect(err).NotTo(HaveOccurred())
+
+			labels := map[string]string{"team": "a", "globally-sync": "true"}
+			matching, err := multiEngineFactory.GetMatchingEngines(ctx, labels)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(HaveLen(1))
+
+			labels2 := map[string]string{"team": "a"}
+			matching, err = multiEngineFactory.GetMatchingEngines(ctx, labels2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(BeEmpty())
+		})
+	})
+
+	Context("Path templates per engine", func() {
+		It("Should apply different path templates for each engine", func() {
+			engines := []configv1alpha1.SecretEngineConfig{
+				{
+					Name:         "team-a",
+					MountPath:    "secret-a",
+					PathTemplate: "team-a/{{.Region}}/{{.Hostname}}/{{.Username}}",
+					SyncLabel:    "team=a",
+				},
+				{
+					Name:         "team-b",
+					MountPath:    "secret-b",
+					PathTemplate: "prod/{{.Region}}/bmc/{{.Hostname}}/user-{{.Username}}",
+					SyncLabel:    "team=b",
+				},
+			}
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+
+			builderA, err := multiEngineFactory.GetPathBuilderForEngine(ctx, "team-a")
+			Expect(err).NotTo(HaveOccurred())
+
+			pathA, err := builderA.Build(secretbackend.PathVariables{
+				Region:   "us-east-1",
+				Hostname: "server1.com",
+				Username: "admin",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pathA).To(Equal("team-a/us-east-1/server1.com/admin"))
+		})
+	})
+
+	Context("Mount paths per engine", func() {
+		It("Should track different mount paths for each engine", func() {
+			engines := []configv1alpha1.SecretEngineConfig{
+				{
+					Name:      "team-a",
+					MountPath: "kv-team-a",
+					SyncLabel: "team=a",
+				},
+				{
+					Name:      "team-b",
+					MountPath: "kv-team-b",
+					SyncLabel: "team=b",
+				},
+			}
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+
+			allEngines, err := multiEngineFactory.GetSecretEngines(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allEngines).To(HaveLen(2))
+		})
+	})
+
+	Context("Error isolation between engines", func() {
+		It("Should continue syncing if one engine fails", func() {
+			engines := []configv1alpha1.SecretEngineConfig{
+				{
+					Name:      "backend-1",
+					MountPath: "secret",
+					SyncLabel: "env=prod",
+				},
+				{
+					Name:      "backend-2",
+					MountPath: "secret",
+					SyncLabel: "env=prod",
+				},
+			}
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+
+			backendMock1 := multiEngineFactory.GetMockBackendForEngine("backend-1")
+			backendMock1.WriteError = fmt.Errorf("connection error")
+
+			labels := map[string]string{"env": "prod"}
+			matchingEngines, err := multiEngineFactory.GetMatchingEngines(ctx, labels)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matchingEngines).To(HaveLen(2))
+
+			backendMock2 := multiEngineFactory.GetMockBackendForEngine("backend-2")
+			_, err = backendMock2.WriteSecret(ctx, "backend2/us-east-1/bmc-server/admin", map[string]any{
+				"username": "admin",
+				"password": "secret123",
+			}, secretbackend.WriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backendMock2.GetWriteCallCount()).To(Equal(1))
+		})
+
+		It("Should sync the other engines and keep the paths of an engine that cannot be created", func() {
+			scheme := runtime.NewScheme()
+			Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+			recorder := record.NewFakeRecorder(100)
+
+			multiEngineFactory, err := mock.NewMultiEngineBackendFactory([]configv1alpha1.SecretEngineConfig{
+				{Name: "team-a", MountPath: "team-a", PathTemplate: "bmc/team-a/{{.Hostname}}", SyncLabel: "env=prod"},
+				{Name: "team-b", MountPath: "team-b", PathTemplate: "bmc/team-b/{{.Hostname}}", SyncLabel: "env=prod"},
+			}, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+			multiEngineFactory.OrphanPolicy = secretbackend.OrphanPolicyDelete
+			multiEngineFactory.EngineErrs = map[string]error{"team-b": fmt.Errorf("auth secret not found")}
+
+			hostname := testBMCHostname
+			bmc := &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-bmc"},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "isolated-secret"},
+					Hostname:     &hostname,
+				},
+			}
+			bmcSecret := &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "isolated-secret",
+					Labels:     map[string]string{"env": "prod"},
+					Finalizers: []string{bmcSecretFinalizer},
+				},
+				Data: map[string][]byte{"username": []byte("admin"), "password": []byte("secret123")},
+			}
+			teamBPath := "bmc/team-b/" + hostname
+			syncStatus := &configv1alpha1.BMCSecretSyncStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "isolated-secret-sync-status"},
+				Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "isolated-secret"},
+				Status: configv1alpha1.BMCSecretSyncStatusStatus{
+					BackendPaths: []configv1alpha1.BackendPath{
+						{Path: teamBPath, Engine: "team-b", BMCName: "test-bmc", SyncStatus: "Success"},
+					},
+				},
+			}
+
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithStatusSubresource(&configv1alpha1.BMCSecretSyncStatus{}).
+				WithObjects(bmcSecret, bmc, syncStatus).
+				Build()
+			reconciler := &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: multiEngineFactory,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "isolated-secret"},
+			})
+			Expect(err).To(MatchError(ContainSubstring("secret engine team-b: auth secret not found")))
+			Expect(result.RequeueAfter).To(Equal(requeueAfterError))
+
+			Expect(multiEngineFactory.GetMockBackendForEngine("team-a").WriteSecretCalls).To(ConsistOf(HaveField("Path", "bmc/team-a/"+hostname)))
+			Expect(multiEngineFactory.GetMockBackendForEngine("team-b").DeleteSecretCalls).To(BeEmpty())
+
+			updated := &configv1alpha1.BMCSecretSyncStatus{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "isolated-secret-sync-status"}, updated)).To(Succeed())
+			Expect(updated.Status.OrphanedPaths).To(BeEmpty())
+			Expect(updated.Status.BackendPaths).To(ConsistOf(
+				And(HaveField("Engine", "team-a"), HaveField("SyncStatus", "Success")),
+				And(HaveField("Path", teamBPath), HaveField("Engine", "team-b"), HaveField("SyncStatus", "Failed"),
+					HaveField("ErrorMessage", ContainSubstring("auth secret not found"))),
+			))
+		})
+	})
+
+	Context("Backward compatibility", func() {
 		It("Should work with no engines configured", func() {
 			engines := []configv1alpha1.SecretEngineConfig{}
 			multiEngineFactory, err := mock.NewMultiEngineBackendFactory(engines, "", "region")
@@ -1000,4 +2604,132 @@ var _ = Describe("BMCSecret Multi-Engine Controller", func() {
 			Expect(matchingEngines).To(BeEmpty())
 		})
 	})
+
+	Context("Deletion with multiple engines", func() {
+		var (
+			scheme             *runtime.Scheme
+			recorder           *record.FakeRecorder
+			multiEngineFactory *mock.MultiEngineBackendFactory
+			bmcSecret          *metalv1alpha1.BMCSecret
+			bmc                *metalv1alpha1.BMC
+		)
+
+		BeforeEach(func() {
+			scheme = runtime.NewScheme()
+			Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+			recorder = record.NewFakeRecorder(100)
+
+			var err error
+			multiEngineFactory, err = mock.NewMultiEngineBackendFactory([]configv1alpha1.SecretEngineConfig{
+				{
+					Name:         "team-a",
+					MountPath:    "team-a",
+					PathTemplate: "bmc/team-a/{{.Region}}/{{.Hostname}}/{{.Username}}",
+					SyncLabel:    "team-a",
+				},
+				{
+					Name:         "team-b",
+					MountPath:    "team-b",
+					PathTemplate: "bmc/team-b/{{.Region}}/{{.Hostname}}/{{.Username}}",
+					SyncLabel:    "team-b",
+				},
+			}, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+
+			now := metav1.Now()
+			bmcSecret = &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-secret",
+					Labels:            map[string]string{"team-a": "true", "team-b": "true"},
+					Finalizers:        []string{bmcSecretFinalizer},
+					DeletionTimestamp: &now,
+				},
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("secret123"),
+				},
+			}
+
+			hostname := testBMCHostname
+			bmc = &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-bmc-1",
+					Labels: map[string]string{"region": "us-east-1"},
+				},
+				Spec: metalv1alpha1.BMCSpec{
+					BMCSecretRef: corev1.LocalObjectReference{Name: "test-secret"},
+					Hostname:     &hostname,
+				},
+			}
+		})
+
+		reconcileDeletion := func(objs ...client.Object) client.Client {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&metalv1alpha1.BMC{}, bmcresolver.BMCSecretRefField, bmcresolver.IndexBMCSecretRef).
+				WithIndex(&configv1alpha1.BMCSecretSyncStatus{}, backendPathField, indexBackendPaths).
+				WithObjects(objs...).
+				Build()
+
+			reconciler := &BMCSecretReconciler{
+				Client:         k8sClient,
+				Scheme:         scheme,
+				Recorder:       recorder,
+				BackendFactory: multiEngineFactory,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "test-secret"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return k8sClient
+		}
+
+		It("Should delete exactly the paths recorded in the sync status", func() {
+			syncStatus := &configv1alpha1.BMCSecretSyncStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-secret-sync-status"},
+				Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "test-secret"},
+				Status: configv1alpha1.BMCSecretSyncStatusStatus{
+					BackendPaths: []configv1alpha1.BackendPath{
+						{Path: "bmc/team-a/us-east-1/old-host/admin", Engine: "team-a", SyncStatus: "Success"},
+						{Path: "bmc/team-b/us-east-1/old-host/admin", Engine: "team-b", SyncStatus: "Success"},
+						{Path: "bmc/team-c/us-east-1/old-host/admin", Engine: "team-c", SyncStatus: "Success"},
+						{Path: "", Engine: "team-a", SyncStatus: "Failed"},
+					},
+				},
+			}
+
+			k8sClient := reconcileDeletion(bmcSecret, bmc, syncStatus)
+
+			Expect(multiEngineFactory.GetMockBackendForEngine("team-a").DeleteSecretCalls).To(ConsistOf("bmc/team-a/us-east-1/old-host/admin"))
+			Expect(multiEngineFactory.GetMockBackendForEngine("team-b").DeleteSecretCalls).To(ConsistOf("bmc/team-b/us-east-1/old-host/admin"))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("team-c")))
+
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: "test-secret-sync-status"}, &configv1alpha1.BMCSecretSyncStatus{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should delete the paths of all matching engines without a sync status", func() {
+			reconcileDeletion(bmcSecret, bmc)
+
+			Expect(multiEngineFactory.GetMockBackendForEngine("team-a").DeleteSecretCalls).To(ConsistOf("bmc/team-a/us-east-1/bmc-server1.example.com/admin"))
+			Expect(multiEngineFactory.GetMockBackendForEngine("team-b").DeleteSecretCalls).To(ConsistOf("bmc/team-b/us-east-1/bmc-server1.example.com/admin"))
+		})
+
+		It("Should apply the deletion policy of each engine", func() {
+			var err error
+			multiEngineFactory, err = mock.NewMultiEngineBackendFactory([]configv1alpha1.SecretEngineConfig{
+				{Name: "team-a", MountPath: "team-a", SyncLabel: "team-a", DeletionPolicy: secretbackend.DeletionPolicyRetain},
+				{Name: "team-b", MountPath: "team-b", SyncLabel: "team-b"},
+			}, "", "region")
+			Expect(err).NotTo(HaveOccurred())
+			multiEngineFactory.DeletionPolicy = secretbackend.DeletionPolicySoftDelete
+
+			reconcileDeletion(bmcSecret, bmc)
+
+			Expect(multiEngineFactory.GetMockBackendForEngine("team-a").DeleteSecretCalls).To(BeEmpty())
+			Expect(multiEngineFactory.GetMockBackendForEngine("team-b").SoftDeleteCalls).To(ConsistOf("bmc/us-east-1/bmc-server1.example.com/admin"))
+		})
+	})
 })
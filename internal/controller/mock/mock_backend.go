@@ -21,20 +21,28 @@ import (
 	"fmt"
 	"maps"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
 )
 
 // MockBackend implements a mock Backend for testing
 type MockBackend struct {
-	mu      sync.RWMutex
-	secrets map[string]map[string]any
+	mu       sync.RWMutex
+	secrets  map[string]map[string]any
+	metadata map[string]map[string]string
+	versions map[string]secretbackend.SecretVersion
 
 	// Track operations for testing
 	WriteSecretCalls  []WriteSecretCall
 	ReadSecretCalls   []string
 	DeleteSecretCalls []string
+	SoftDeleteCalls   []string
 	SecretExistsCalls []string
+	CheckHealthCalls  int
 	CloseCalled       bool
 
 	// Configure mock behavior
@@ -42,29 +50,60 @@ type MockBackend struct {
 	ReadError         error
 	DeleteError       error
 	SecretExistsError error
+	HealthError       error
+	WriteDelay        time.Duration
+
+	// BeforeWrite is called before a write is applied, e.g. to simulate a concurrent writer
+	BeforeWrite func(path string)
+
+	// Track concurrent writes
+	inFlightWrites    atomic.Int32
+	maxInFlightWrites atomic.Int32
 }
 
 type WriteSecretCall struct {
-	Path string
-	Data map[string]any
+	Path     string
+	Data     map[string]any
+	Metadata map[string]string
+	CAS      *int
 }
 
 // NewMockBackend creates a new mock backend
 func NewMockBackend() *MockBackend {
 	return &MockBackend{
-		secrets: make(map[string]map[string]any),
+		secrets:  make(map[string]map[string]any),
+		metadata: make(map[string]map[string]string),
+		versions: make(map[string]secretbackend.SecretVersion),
 	}
 }
 
 // WriteSecret writes a secret to the mock backend
-func (m *MockBackend) WriteSecret(ctx context.Context, path string, data map[string]any) error {
+func (m *MockBackend) WriteSecret(ctx context.Context, path string, data map[string]any, opts secretbackend.WriteOptions) (secretbackend.SecretVersion, error) {
+	inFlight := m.inFlightWrites.Add(1)
+	defer m.inFlightWrites.Add(-1)
+	for {
+		maxInFlight := m.maxInFlightWrites.Load()
+		if inFlight <= maxInFlight || m.maxInFlightWrites.CompareAndSwap(maxInFlight, inFlight) {
+			break
+		}
+	}
+	if m.WriteDelay > 0 {
+		time.Sleep(m.WriteDelay)
+	}
+	if m.BeforeWrite != nil {
+		m.BeforeWrite(path)
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.WriteSecretCalls = append(m.WriteSecretCalls, WriteSecretCall{Path: path, Data: data})
+	m.WriteSecretCalls = append(m.WriteSecretCalls, WriteSecretCall{Path: path, Data: data, Metadata: opts.Metadata, CAS: opts.CAS})
 
 	if m.WriteError != nil {
-		return m.WriteError
+		return secretbackend.SecretVersion{}, m.WriteError
+	}
+	if opts.CAS != nil && *opts.CAS != m.versions[path].Version {
+		return secretbackend.SecretVersion{}, fmt.Errorf("%w at %s", secretbackend.ErrCASMismatch, path)
 	}
 
 	// Deep copy data
@@ -72,13 +111,21 @@ func (m *MockBackend) WriteSecret(ctx context.Context, path string, data map[str
 	maps.Copy(dataCopy, data)
 	m.secrets[path] = dataCopy
 
-	return nil
+	// Custom metadata keys are merged like with KV v2
+	if m.metadata[path] == nil {
+		m.metadata[path] = make(map[string]string)
+	}
+	maps.Copy(m.metadata[path], opts.Metadata)
+	written := secretbackend.SecretVersion{Version: m.versions[path].Version + 1, CreatedTime: time.Now()}
+	m.versions[path] = written
+
+	return written, nil
 }
 
 // ReadSecret reads a secret from the mock backend
-func (m *MockBackend) ReadSecret(ctx context.Context, path string) (map[string]any, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+func (m *MockBackend) ReadSecret(ctx context.Context, path string) (*secretbackend.Secret, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
 	m.ReadSecretCalls = append(m.ReadSecretCalls, path)
 
@@ -88,35 +135,48 @@ func (m *MockBackend) ReadSecret(ctx context.Context, path string) (map[string]a
 
 	data, exists := m.secrets[path]
 	if !exists {
-		return nil, fmt.Errorf("secret not found at %s", path)
+		return nil, fmt.Errorf("%w at %s", secretbackend.ErrSecretNotFound, path)
 	}
 
 	// Deep copy data
 	dataCopy := make(map[string]any)
 	maps.Copy(dataCopy, data)
 
-	return dataCopy, nil
+	return &secretbackend.Secret{
+		Data:          dataCopy,
+		Metadata:      maps.Clone(m.metadata[path]),
+		SecretVersion: m.versions[path],
+	}, nil
 }
 
 // DeleteSecret deletes a secret from the mock backend
-func (m *MockBackend) DeleteSecret(ctx context.Context, path string) error {
+func (m *MockBackend) DeleteSecret(ctx context.Context, path string, opts secretbackend.DeleteOptions) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.DeleteSecretCalls = append(m.DeleteSecretCalls, path)
+	if opts.SoftDelete {
+		m.SoftDeleteCalls = append(m.SoftDeleteCalls, path)
+	}
 
 	if m.DeleteError != nil {
 		return m.DeleteError
 	}
 
 	delete(m.secrets, path)
+	if opts.SoftDelete {
+		// Like KV v2, the metadata and version count survive a soft delete
+		return nil
+	}
+	delete(m.metadata, path)
+	delete(m.versions, path)
 	return nil
 }
 
 // SecretExists checks if a secret exists in the mock backend
 func (m *MockBackend) SecretExists(ctx context.Context, path string) (bool, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
 	m.SecretExistsCalls = append(m.SecretExistsCalls, path)
 
@@ -128,6 +188,15 @@ func (m *MockBackend) SecretExists(ctx context.Context, path string) (bool, erro
 	return exists, nil
 }
 
+// CheckHealth returns the configured health error
+func (m *MockBackend) CheckHealth(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.CheckHealthCalls++
+	return m.HealthError
+}
+
 // Close closes the mock backend
 func (m *MockBackend) Close() error {
 	m.mu.Lock()
@@ -143,15 +212,20 @@ func (m *MockBackend) Reset() {
 	defer m.mu.Unlock()
 
 	m.secrets = make(map[string]map[string]any)
+	m.metadata = make(map[string]map[string]string)
+	m.versions = make(map[string]secretbackend.SecretVersion)
 	m.WriteSecretCalls = nil
 	m.ReadSecretCalls = nil
 	m.DeleteSecretCalls = nil
+	m.SoftDeleteCalls = nil
 	m.SecretExistsCalls = nil
+	m.CheckHealthCalls = 0
 	m.CloseCalled = false
 	m.WriteError = nil
 	m.ReadError = nil
 	m.DeleteError = nil
 	m.SecretExistsError = nil
+	m.HealthError = nil
 }
 
 // GetWriteCallCount returns the number of WriteSecret calls
@@ -161,6 +235,11 @@ func (m *MockBackend) GetWriteCallCount() int {
 	return len(m.WriteSecretCalls)
 }
 
+// GetMaxConcurrentWrites returns the highest number of writes that were in flight at once
+func (m *MockBackend) GetMaxConcurrentWrites() int {
+	return int(m.maxInFlightWrites.Load())
+}
+
 // GetDeleteCallCount returns the number of DeleteSecret calls
 func (m *MockBackend) GetDeleteCallCount() int {
 	m.mu.RLock()
@@ -177,10 +256,21 @@ func (m *MockBackend) GetSecretCount() int {
 
 // MockBackendFactory creates a factory that returns the mock backend
 type MockBackendFactory struct {
-	Backend          *MockBackend
-	PathBuilder      *secretbackend.PathBuilder
-	RegionLabelKey   string
-	SyncLabel        string
+	Backend        *MockBackend
+	PathBuilder    *secretbackend.PathBuilder
+	RegionLabelKey string
+	SyncLabel      string
+	OrphanPolicy   string
+	ClusterID      string
+	ConfigName     string
+	AdoptPolicy    string
+	DeletionPolicy string
+	MaxConcurrency int
+	VerifyInterval time.Duration
+	ContentHashKey []byte
+	Location       secretbackend.Location
+	// LocationBackends are returned by NewLocationBackend by location
+	LocationBackends map[secretbackend.Location]*MockBackend
 	GetBackendErr    error
 	EngineBackends   []*secretbackend.EngineBackend
 	HasMultiEngine   bool
@@ -199,6 +289,12 @@ func NewMockBackendFactory(mockBackend *MockBackend, pathTemplate, regionLabelKe
 		PathBuilder:    pathBuilder,
 		RegionLabelKey: regionLabelKey,
 		SyncLabel:      syncLabel,
+		OrphanPolicy:   secretbackend.OrphanPolicyDelete,
+		ConfigName:     secretbackend.DefaultBackendConfigName,
+		AdoptPolicy:    secretbackend.AdoptPolicyRefuse,
+		DeletionPolicy: secretbackend.DeletionPolicyDestroy,
+		MaxConcurrency: secretbackend.DefaultMaxConcurrentSyncs,
+		VerifyInterval: secretbackend.DefaultVerificationInterval,
 	}, nil
 }
 
@@ -217,8 +313,54 @@ func (m *MockBackendFactory) GetRegionLabelKey(ctx context.Context) (string, err
 	return m.RegionLabelKey, nil
 }
 
-func (m *MockBackendFactory) GetSyncLabel(ctx context.Context) (string, error) {
-	return m.SyncLabel, nil
+// GetSyncSelector parses SyncLabel as a label selector in string form
+func (m *MockBackendFactory) GetSyncSelector(ctx context.Context) (labels.Selector, error) {
+	return secretbackend.SyncSelector(nil, m.SyncLabel)
+}
+
+func (m *MockBackendFactory) GetOrphanPolicy(ctx context.Context) (string, error) {
+	return m.OrphanPolicy, nil
+}
+
+func (m *MockBackendFactory) GetClusterID(ctx context.Context) (string, error) {
+	return m.ClusterID, nil
+}
+
+func (m *MockBackendFactory) GetConfigName(ctx context.Context) (string, error) {
+	return m.ConfigName, nil
+}
+
+func (m *MockBackendFactory) GetAdoptPolicy(ctx context.Context) (string, error) {
+	return m.AdoptPolicy, nil
+}
+
+func (m *MockBackendFactory) GetDeletionPolicy(ctx context.Context) (string, error) {
+	return m.DeletionPolicy, nil
+}
+
+func (m *MockBackendFactory) GetMaxConcurrentSyncs(ctx context.Context) (int, error) {
+	return m.MaxConcurrency, nil
+}
+
+func (m *MockBackendFactory) GetVerificationInterval(ctx context.Context) (time.Duration, error) {
+	return m.VerifyInterval, nil
+}
+
+func (m *MockBackendFactory) GetContentHashKey(ctx context.Context) ([]byte, error) {
+	return m.ContentHashKey, nil
+}
+
+func (m *MockBackendFactory) GetLocation(ctx context.Context) (secretbackend.Location, error) {
+	return m.Location, nil
+}
+
+// NewLocationBackend returns the backend of LocationBackends at the location
+func (m *MockBackendFactory) NewLocationBackend(ctx context.Context, engineName string, location secretbackend.Location) (secretbackend.Backend, error) {
+	backend, ok := m.LocationBackends[location]
+	if !ok {
+		return nil, fmt.Errorf("no backend at location %v", location)
+	}
+	return backend, nil
 }
 
 func (m *MockBackendFactory) Close() error {
@@ -237,9 +379,41 @@ func (m *MockBackendFactory) GetEngineBackends(ctx context.Context, labels map[s
 	return m.EngineBackends, nil
 }
 
+func (m *MockBackendFactory) GetAllEngineBackends(ctx context.Context) ([]*secretbackend.EngineBackend, error) {
+	if m.EngineBackendErr != nil {
+		return nil, m.EngineBackendErr
+	}
+	return m.EngineBackends, nil
+}
+
 func (m *MockBackendFactory) HasMultiEngineConfig(ctx context.Context) (bool, error) {
 	if m.MultiEngineErr != nil {
 		return false, m.MultiEngineErr
 	}
 	return m.HasMultiEngine, nil
 }
+
+// MockBackendRegistry is a mock implementation of BackendRegistryInterface
+type MockBackendRegistry struct {
+	Factories map[string]secretbackend.BackendFactoryInterface
+	Err       error
+}
+
+func (m *MockBackendRegistry) GetFactories(ctx context.Context) (map[string]secretbackend.BackendFactoryInterface, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Factories, nil
+}
+
+// SyncSelectors returns the sync selector of every mock factory, nil if it fails
+func (m *MockBackendRegistry) SyncSelectors(ctx context.Context) (map[string]labels.Selector, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	selectors := make(map[string]labels.Selector, len(m.Factories))
+	for name, factory := range m.Factories {
+		selectors[name], _ = factory.GetSyncSelector(ctx)
+	}
+	return selectors, nil
+}
@@ -18,11 +18,14 @@ package mock
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
 	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // MultiEngineBackendFactory manages multiple backend instances for different engines
@@ -33,8 +36,21 @@ type MultiEngineBackendFactory struct {
 	globalSyncLabel string
 	pathBuilders    map[string]*secretbackend.PathBuilder
 	regionLabelKey  string
-	GetBackendErr   error
-	GetEngineErr    error
+	OrphanPolicy    string
+	ClusterID       string
+	ConfigName      string
+	AdoptPolicy     string
+	DeletionPolicy  string
+	MaxConcurrency  int
+	VerifyInterval  time.Duration
+	ContentHashKey  []byte
+	Location        secretbackend.Location
+	// LocationBackends are returned by NewLocationBackend by location
+	LocationBackends map[secretbackend.Location]*MockBackend
+	GetBackendErr    error
+	GetEngineErr     error
+	// EngineErrs makes the named engines fail to create their backend
+	EngineErrs map[string]error
 }
 
 // NewMultiEngineBackendFactory creates a factory supporting multiple engines
@@ -49,6 +65,12 @@ func NewMultiEngineBackendFactory(
 		globalSyncLabel: globalSyncLabel,
 		pathBuilders:    make(map[string]*secretbackend.PathBuilder),
 		regionLabelKey:  regionLabelKey,
+		OrphanPolicy:    secretbackend.OrphanPolicyDelete,
+		ConfigName:      secretbackend.DefaultBackendConfigName,
+		AdoptPolicy:     secretbackend.AdoptPolicyRefuse,
+		DeletionPolicy:  secretbackend.DeletionPolicyDestroy,
+		MaxConcurrency:  secretbackend.DefaultMaxConcurrentSyncs,
+		VerifyInterval:  secretbackend.DefaultVerificationInterval,
 	}
 
 	// Create backends and path builders for each engine
@@ -145,16 +167,14 @@ func (f *MultiEngineBackendFactory) GetMatchingEngines(ctx context.Context, labe
 
 	var matching []configv1alpha1.SecretEngineConfig
 
-	// If global syncLabel is set, check it first
-	if f.globalSyncLabel != "" {
-		if _, exists := labels[f.globalSyncLabel]; !exists {
-			return matching, nil
-		}
+	// If a global sync selector is set, check it first
+	if !matchesLabel(labels, f.globalSyncLabel) {
+		return matching, nil
 	}
 
-	// Check each engine's syncLabel
+	// Check each engine's sync selector
 	for _, engine := range f.engines {
-		if matchesLabel(labels, engine.SyncLabel) {
+		if matchesEngine(labels, engine) {
 			matching = append(matching, engine)
 		}
 	}
@@ -162,54 +182,109 @@ func (f *MultiEngineBackendFactory) GetMatchingEngines(ctx context.Context, labe
 	return matching, nil
 }
 
-// matchesLabel checks if a label map matches the label spec
-// Spec format: "key" or "key=value"
-// "key" matches if the key exists with any value
-// "key=value" matches if the key exists with exactly that value
-func matchesLabel(labels map[string]string, labelSpec string) bool {
-	if labelSpec == "" {
+// matchesLabel checks if a label map matches a label selector in string form
+// An empty selector matches everything, an invalid one nothing.
+func matchesLabel(bmcSecretLabels map[string]string, syncLabel string) bool {
+	selector, err := secretbackend.SyncSelector(nil, syncLabel)
+	return err == nil && selector.Matches(labels.Set(bmcSecretLabels))
+}
+
+// matchesEngine checks if a label map matches the sync selector of an engine
+func matchesEngine(bmcSecretLabels map[string]string, engine configv1alpha1.SecretEngineConfig) bool {
+	if engine.SyncSelector == nil && engine.SyncLabel == "" {
 		return false
 	}
+	selector, err := secretbackend.SyncSelector(engine.SyncSelector, engine.SyncLabel)
+	return err == nil && selector.Matches(labels.Set(bmcSecretLabels))
+}
 
-	// Parse label spec
-	var key, expectedValue string
-	for i, ch := range labelSpec {
-		if ch == '=' {
-			key = labelSpec[:i]
-			expectedValue = labelSpec[i+1:]
-			break
-		}
-	}
+// GetRegionLabelKey returns the configured region label key
+func (f *MultiEngineBackendFactory) GetRegionLabelKey(ctx context.Context) (string, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.regionLabelKey, nil
+}
 
-	if key == "" {
-		key = labelSpec
-	}
+// GetSyncSelector returns the global sync selector
+func (f *MultiEngineBackendFactory) GetSyncSelector(ctx context.Context) (labels.Selector, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return secretbackend.SyncSelector(nil, f.globalSyncLabel)
+}
 
-	value, exists := labels[key]
-	if !exists {
-		return false
-	}
+// GetOrphanPolicy returns the configured orphan policy
+func (f *MultiEngineBackendFactory) GetOrphanPolicy(ctx context.Context) (string, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.OrphanPolicy, nil
+}
 
-	// If no expected value is specified, any value matches
-	if expectedValue == "" {
-		return true
-	}
+// GetClusterID returns the ID of the cluster recorded in ownership markers
+func (f *MultiEngineBackendFactory) GetClusterID(ctx context.Context) (string, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.ClusterID, nil
+}
 
-	return value == expectedValue
+// GetConfigName returns the name of the configuration recorded in ownership markers
+func (f *MultiEngineBackendFactory) GetConfigName(ctx context.Context) (string, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.ConfigName, nil
 }
 
-// GetRegionLabelKey returns the configured region label key
-func (f *MultiEngineBackendFactory) GetRegionLabelKey(ctx context.Context) (string, error) {
+// GetAdoptPolicy returns the configured adopt policy
+func (f *MultiEngineBackendFactory) GetAdoptPolicy(ctx context.Context) (string, error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
-	return f.regionLabelKey, nil
+	return f.AdoptPolicy, nil
 }
 
-// GetSyncLabel returns the global sync label
-func (f *MultiEngineBackendFactory) GetSyncLabel(ctx context.Context) (string, error) {
+// GetDeletionPolicy returns the configured deletion policy
+func (f *MultiEngineBackendFactory) GetDeletionPolicy(ctx context.Context) (string, error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
-	return f.globalSyncLabel, nil
+	return f.DeletionPolicy, nil
+}
+
+// GetMaxConcurrentSyncs returns the number of paths synced in parallel per engine
+func (f *MultiEngineBackendFactory) GetMaxConcurrentSyncs(ctx context.Context) (int, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.MaxConcurrency, nil
+}
+
+// GetVerificationInterval returns how often synced secrets are read back and compared in full
+func (f *MultiEngineBackendFactory) GetVerificationInterval(ctx context.Context) (time.Duration, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.VerifyInterval, nil
+}
+
+// GetContentHashKey returns the key of the recorded content hashes
+func (f *MultiEngineBackendFactory) GetContentHashKey(ctx context.Context) ([]byte, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.ContentHashKey, nil
+}
+
+// GetLocation returns the location of the configuration
+func (f *MultiEngineBackendFactory) GetLocation(ctx context.Context) (secretbackend.Location, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.Location, nil
+}
+
+// NewLocationBackend returns the backend of LocationBackends at the location
+func (f *MultiEngineBackendFactory) NewLocationBackend(ctx context.Context, engineName string, location secretbackend.Location) (secretbackend.Backend, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	backend, ok := f.LocationBackends[location]
+	if !ok {
+		return nil, fmt.Errorf("no backend at location %v", location)
+	}
+	return backend, nil
 }
 
 // GetSecretEngines returns all configured secret engines
@@ -246,11 +321,38 @@ func (f *MultiEngineBackendFactory) GetEngineBackends(ctx context.Context, label
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
+	return f.engineBackends(func(engine configv1alpha1.SecretEngineConfig) bool {
+		return matchesEngine(labels, engine)
+	})
+}
+
+// GetAllEngineBackends returns all engine backends regardless of labels
+func (f *MultiEngineBackendFactory) GetAllEngineBackends(ctx context.Context) ([]*secretbackend.EngineBackend, error) {
+	if f.GetEngineErr != nil {
+		return nil, f.GetEngineErr
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.engineBackends(func(configv1alpha1.SecretEngineConfig) bool {
+		return true
+	})
+}
+
+// engineBackends builds engine backends for the engines accepted by match, and
+// EngineErrors for the accepted engines configured to fail
+func (f *MultiEngineBackendFactory) engineBackends(match func(configv1alpha1.SecretEngineConfig) bool) ([]*secretbackend.EngineBackend, error) {
 	var engineBackends []*secretbackend.EngineBackend
+	var engineErrs []error
 
-	// Check each engine's syncLabel
 	for _, engine := range f.engines {
-		if !matchesLabel(labels, engine.SyncLabel) {
+		if !match(engine) {
+			continue
+		}
+
+		if err, failed := f.EngineErrs[engine.Name]; failed {
+			engineErrs = append(engineErrs, &secretbackend.EngineError{Engine: engine.Name, Err: err})
 			continue
 		}
 
@@ -266,22 +368,47 @@ func (f *MultiEngineBackendFactory) GetEngineBackends(ctx context.Context, label
 			continue
 		}
 
-		// Parse sync label
-		syncLabelKey, syncLabelVal := parseSyncLabel(engine.SyncLabel)
+		syncSelector, err := secretbackend.SyncSelector(engine.SyncSelector, engine.SyncLabel)
+		if err != nil {
+			continue
+		}
+
+		maxConcurrentSyncs := f.MaxConcurrency
+		if engine.MaxConcurrentSyncs != nil {
+			maxConcurrentSyncs = int(*engine.MaxConcurrentSyncs)
+		}
+
+		deletionPolicy := f.DeletionPolicy
+		if engine.DeletionPolicy != "" {
+			deletionPolicy = engine.DeletionPolicy
+		}
+
+		location := secretbackend.Location{
+			Address:   f.Location.Address,
+			Namespace: f.Location.Namespace,
+			MountPath: engine.MountPath,
+		}
+		if engine.Address != "" {
+			location.Address = engine.Address
+		}
+		if engine.Namespace != "" {
+			location.Namespace = engine.Namespace
+		}
 
 		engineBackend := &secretbackend.EngineBackend{
-			Backend:      backend,
-			EngineName:   engine.Name,
-			PathBuilder:  pathBuilder,
-			SyncLabel:    engine.SyncLabel,
-			SyncLabelKey: syncLabelKey,
-			SyncLabelVal: syncLabelVal,
+			Backend:            backend,
+			EngineName:         engine.Name,
+			PathBuilder:        pathBuilder,
+			Location:           location,
+			SyncSelector:       syncSelector,
+			MaxConcurrentSyncs: maxConcurrentSyncs,
+			DeletionPolicy:     deletionPolicy,
 		}
 
 		engineBackends = append(engineBackends, engineBackend)
 	}
 
-	return engineBackends, nil
+	return engineBackends, errors.Join(engineErrs...)
 }
 
 // HasMultiEngineConfig checks if multi-engine configuration is present
@@ -291,16 +418,6 @@ func (f *MultiEngineBackendFactory) HasMultiEngineConfig(ctx context.Context) (b
 	return len(f.engines) > 0, nil
 }
 
-// parseSyncLabel splits a sync label into key and value
-func parseSyncLabel(syncLabel string) (string, string) {
-	for i, ch := range syncLabel {
-		if ch == '=' {
-			return syncLabel[:i], syncLabel[i+1:]
-		}
-	}
-	return syncLabel, ""
-}
-
 // GetMockBackendForEngine returns the underlying MockBackend for assertions in tests
 func (f *MultiEngineBackendFactory) GetMockBackendForEngine(engineName string) *MockBackend {
 	f.mu.RLock()
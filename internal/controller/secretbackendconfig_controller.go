@@ -18,14 +18,28 @@ package controller
 
 import (
 	"context"
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
 )
@@ -33,14 +47,47 @@ import (
 // SecretBackendConfigReconciler reconciles a SecretBackendConfig object
 type SecretBackendConfigReconciler struct {
 	client.Client
-	Scheme         *runtime.Scheme
-	Recorder       record.EventRecorder
-	BackendFactory *secretbackend.BackendFactory
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Registry *secretbackend.BackendRegistry
+
+	// ConfigChanges is notified after the cache was invalidated, so the BMCSecret
+	// controller re-enqueues all BMCSecrets with the new configuration
+	ConfigChanges chan<- event.GenericEvent
+
+	// Prober connects to the backends of a config to determine its conditions.
+	// Without it only the CredentialsAvailable condition is reported.
+	Prober secretbackend.BackendProberInterface
+
+	// ProbeInterval is how often the backends are probed again (defaults to 5 minutes)
+	ProbeInterval time.Duration
+
+	// SecretMetadataReader reads the metadata of the referenced secrets. SetupWithManager
+	// defaults it to the manager's cache, which serves them from the metadata informer
+	// secrets are watched with, as the client reads secrets from the API server.
+	// Without it, the client is used.
+	SecretMetadataReader client.Reader
+
+	// appliedVersions holds the version of every config the backend caches were
+	// last invalidated for, so periodic probes do not invalidate them again
+	appliedVersions map[string]string
+	mu              sync.Mutex
 }
 
+const (
+	// defaultProbeInterval is how often the backends of a config are probed by default
+	defaultProbeInterval = 5 * time.Minute
+
+	conditionReady         = "Ready"
+	conditionAuthenticated = "Authenticated"
+	conditionDegraded      = "Degraded"
+)
+
 // +kubebuilder:rbac:groups=config.metal.ironcore.dev,resources=secretbackendconfigs,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.metal.ironcore.dev,resources=secretbackendconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=config.metal.ironcore.dev,resources=bmcsecretsyncstatuses,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcsecrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile handles SecretBackendConfig changes
 func (r *SecretBackendConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -50,40 +97,363 @@ func (r *SecretBackendConfigReconciler) Reconcile(ctx context.Context, req ctrl.
 	var config configv1alpha1.SecretBackendConfig
 	if err := r.Get(ctx, req.NamespacedName, &config); err != nil {
 		if errors.IsNotFound(err) {
-			// Config was deleted - operator will fall back to environment variables
-			logger.Info("SecretBackendConfig deleted, invalidating cache")
-			if err := r.BackendFactory.InvalidateCache(); err != nil {
-				logger.Error(err, "Failed to invalidate backend cache")
+			// Config was deleted - without any config left the operator falls back to environment variables
+			logger.Info("SecretBackendConfig deleted, removing its backends")
+			if err := r.Registry.RemoveFactory(req.Name); err != nil {
+				logger.Error(err, "Failed to close backends of the deleted config")
+				return ctrl.Result{}, err
+			}
+			r.setAppliedVersion(req.Name, "")
+			if err := r.deleteSyncStatuses(ctx, req.Name); err != nil {
+				logger.Error(err, "Failed to delete BMCSecretSyncStatuses of the deleted config")
 				return ctrl.Result{}, err
 			}
+			r.notifyConfigChange(&configv1alpha1.SecretBackendConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: req.Name},
+			})
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Failed to get SecretBackendConfig")
 		return ctrl.Result{}, err
 	}
 
-	logger.Info("SecretBackendConfig changed, invalidating cache")
+	// Periodic probes reconcile unchanged configs, which must not drop the cached backends
+	version := r.configVersion(ctx, &config)
+	if version != r.appliedVersion(config.Name) {
+		logger.Info("SecretBackendConfig changed, invalidating cache")
+
+		// Invalidate the backend factory cache of this config
+		if err := r.Registry.InvalidateCache(config.Name); err != nil {
+			logger.Error(err, "Failed to invalidate backend cache")
+			return ctrl.Result{}, err
+		}
+		r.setAppliedVersion(config.Name, version)
+
+		// Re-enqueue all BMCSecrets, so changes of the sync label, paths or policies
+		// apply right away instead of on the next periodic sync
+		r.notifyConfigChange(&config)
+
+		logger.Info("Successfully invalidated cache - BMCSecrets are reconciled with the new config")
+		r.Recorder.Event(&config, "Normal", "ConfigReloaded", "Configuration cache invalidated, BMCSecrets are resynced with the new settings")
+	}
+
+	// Surface missing auth secrets on the config; a later change of the
+	// secret triggers a new reconciliation through the secret watch
+	condition := r.checkAuthSecrets(ctx, &config)
+	if condition.Status == metav1.ConditionFalse {
+		r.Recorder.Event(&config, "Warning", condition.Reason, condition.Message)
+	}
+
+	apimeta.SetStatusCondition(&config.Status.Conditions, condition)
 
-	// Invalidate the backend factory cache
-	if err := r.BackendFactory.InvalidateCache(); err != nil {
-		logger.Error(err, "Failed to invalidate backend cache")
+	var result ctrl.Result
+	if r.Prober != nil {
+		r.probeBackends(ctx, &config)
+		result.RequeueAfter = r.ProbeInterval
+		if result.RequeueAfter <= 0 {
+			result.RequeueAfter = defaultProbeInterval
+		}
+	}
+
+	config.Status.ObservedGeneration = config.Generation
+	if err := r.Status().Update(ctx, &config); err != nil {
+		logger.Error(err, "Failed to update SecretBackendConfig status")
 		return ctrl.Result{}, err
 	}
 
-	// Note: We don't need to manually trigger reconciliation of BMCSecrets
-	// The operator will naturally reconcile them on the next periodic sync (5 minutes)
-	// or when BMC/BMCSecret resources are updated
-	// The cache invalidation ensures the next reconciliation uses the new configuration
+	return result, nil
+}
+
+// configVersion identifies the settings a config applies to the backend caches: the
+// generation of its spec and the resource versions of the secrets it references
+func (r *SecretBackendConfigReconciler) configVersion(ctx context.Context, config *configv1alpha1.SecretBackendConfig) string {
+	version := fmt.Sprintf("%d", config.Generation)
+	reader := r.SecretMetadataReader
+	if reader == nil {
+		reader = r.Client
+	}
+	for _, ref := range secretbackend.SecretReferences(config) {
+		var secret metav1.PartialObjectMetadata
+		secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, &secret); err != nil {
+			version += "/"
+			continue
+		}
+		version += "/" + secret.ResourceVersion
+	}
+	return version
+}
 
-	logger.Info("Successfully invalidated cache - new config will be used on next reconciliation")
-	r.Recorder.Event(&config, "Normal", "ConfigReloaded", "Configuration cache invalidated, new settings will be applied on next sync")
+// appliedVersion returns the version of the config the backend caches were last invalidated for
+func (r *SecretBackendConfigReconciler) appliedVersion(configName string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.appliedVersions[configName]
+}
 
-	return ctrl.Result{}, nil
+// setAppliedVersion records the version of the config the backend caches were invalidated for
+func (r *SecretBackendConfigReconciler) setAppliedVersion(configName, version string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if version == "" {
+		delete(r.appliedVersions, configName)
+		return
+	}
+	if r.appliedVersions == nil {
+		r.appliedVersions = make(map[string]string)
+	}
+	r.appliedVersions[configName] = version
+}
+
+// probeBackends connects to the base backend and every secret engine of the config and
+// records the outcome in the Ready, Authenticated and Degraded conditions and the engine
+// statuses. Ready is only True when every backend can be used, Degraded is True while
+// some of them fail and others work.
+func (r *SecretBackendConfigReconciler) probeBackends(ctx context.Context, config *configv1alpha1.SecretBackendConfig) {
+	logger := log.FromContext(ctx)
+	wasReady := apimeta.FindStatusCondition(config.Status.Conditions, conditionReady)
+
+	now := metav1.Now()
+	config.Status.LastProbeTime = &now
+	newCondition := func(conditionType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+		return metav1.Condition{
+			Type:               conditionType,
+			Status:             status,
+			ObservedGeneration: config.Generation,
+			LastTransitionTime: now,
+			Reason:             reason,
+			Message:            message,
+		}
+	}
+
+	results, err := r.Prober.Probe(ctx, config)
+	if err != nil {
+		logger.Error(err, "Invalid SecretBackendConfig")
+		config.Status.Engines = nil
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionReady, metav1.ConditionFalse, "InvalidConfig", err.Error()))
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionAuthenticated, metav1.ConditionUnknown, "InvalidConfig", "The configuration is invalid"))
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionDegraded, metav1.ConditionFalse, "InvalidConfig", "The configuration is invalid"))
+		if wasReady == nil || wasReady.Reason != "InvalidConfig" {
+			r.Recorder.Event(config, "Warning", "InvalidConfig", err.Error())
+		}
+		return
+	}
+
+	var failures, authFailures []string
+	failureReason, authFailureReason := "", ""
+	config.Status.Engines = nil
+	for _, result := range results {
+		reason := probeReason(result.Err)
+		if result.Engine != "" {
+			engineStatus := configv1alpha1.SecretEngineStatus{
+				Name:      result.Engine,
+				MountPath: result.MountPath,
+				Ready:     result.Err == nil,
+				Reason:    reason,
+			}
+			if result.Err != nil {
+				engineStatus.Message = result.Err.Error()
+			}
+			config.Status.Engines = append(config.Status.Engines, engineStatus)
+		}
+		if result.Err == nil {
+			continue
+		}
+
+		message := result.Err.Error()
+		if result.Engine != "" {
+			message = fmt.Sprintf("engine %s: %s", result.Engine, message)
+		}
+		failures = append(failures, message)
+		if failureReason == "" {
+			failureReason = reason
+		}
+		if reason != "MountDetectionFailed" {
+			authFailures = append(authFailures, message)
+			if authFailureReason == "" {
+				authFailureReason = reason
+			}
+		}
+	}
+
+	if len(authFailures) == 0 {
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionAuthenticated, metav1.ConditionTrue, "LoginSucceeded",
+			fmt.Sprintf("Authenticated with %d backends", len(results))))
+	} else {
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionAuthenticated, metav1.ConditionFalse, authFailureReason,
+			strings.Join(authFailures, "; ")))
+	}
+
+	switch {
+	case len(failures) == 0:
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionReady, metav1.ConditionTrue, "BackendsReady",
+			fmt.Sprintf("Authenticated and found the KV mount of %d backends", len(results))))
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionDegraded, metav1.ConditionFalse, "BackendsReady",
+			"All backends are available"))
+	case len(failures) < len(results):
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionReady, metav1.ConditionFalse, failureReason,
+			strings.Join(failures, "; ")))
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionDegraded, metav1.ConditionTrue, "PartiallyAvailable",
+			fmt.Sprintf("%d of %d backends are unavailable", len(failures), len(results))))
+	default:
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionReady, metav1.ConditionFalse, failureReason,
+			strings.Join(failures, "; ")))
+		apimeta.SetStatusCondition(&config.Status.Conditions, newCondition(conditionDegraded, metav1.ConditionFalse, "Unavailable",
+			"No backend is available"))
+	}
+
+	if len(failures) > 0 && (wasReady == nil || wasReady.Status != metav1.ConditionFalse || wasReady.Reason != failureReason) {
+		r.Recorder.Event(config, "Warning", failureReason, strings.Join(failures, "; "))
+	}
+}
+
+// probeReason returns the condition reason for the outcome of probing a backend
+func probeReason(err error) string {
+	switch {
+	case err == nil:
+		return "Ready"
+	case goerrors.Is(err, secretbackend.ErrCredentialsUnavailable):
+		return "CredentialsUnavailable"
+	case goerrors.Is(err, secretbackend.ErrAuthentication):
+		return "AuthenticationFailed"
+	case goerrors.Is(err, secretbackend.ErrMountDetection):
+		return "MountDetectionFailed"
+	default:
+		return "ConnectionFailed"
+	}
+}
+
+// notifyConfigChange signals a configuration change to the BMCSecret controller.
+// It does not block: a pending notification already covers this change, as all
+// BMCSecrets are re-enqueued and read the configuration when reconciled.
+func (r *SecretBackendConfigReconciler) notifyConfigChange(config *configv1alpha1.SecretBackendConfig) {
+	if r.ConfigChanges == nil {
+		return
+	}
+	select {
+	case r.ConfigChanges <- event.GenericEvent{Object: config}:
+	default:
+	}
+}
+
+// deleteSyncStatuses deletes the BMCSecretSyncStatuses recorded for a deleted config.
+// Its backend is no longer known, so the secrets synced with it are left in place.
+func (r *SecretBackendConfigReconciler) deleteSyncStatuses(ctx context.Context, configName string) error {
+	var syncStatuses configv1alpha1.BMCSecretSyncStatusList
+	if err := r.List(ctx, &syncStatuses); err != nil {
+		return err
+	}
+
+	for i := range syncStatuses.Items {
+		if syncStatuses.Items[i].Spec.ConfigRef != configName {
+			continue
+		}
+		if err := r.Delete(ctx, &syncStatuses.Items[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		log.FromContext(ctx).Info("Deleted BMCSecretSyncStatus of deleted SecretBackendConfig", "syncStatus", syncStatuses.Items[i].Name)
+	}
+	return nil
+}
+
+// checkAuthSecrets verifies that the secrets referenced by the auth method exist
+// and contain the referenced keys
+func (r *SecretBackendConfigReconciler) checkAuthSecrets(ctx context.Context, config *configv1alpha1.SecretBackendConfig) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               "CredentialsAvailable",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: config.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "SecretResolved",
+		Message:            "Auth method credentials are available",
+	}
+
+	refs := secretbackend.AuthSecretReferences(config)
+	if len(refs) == 0 {
+		condition.Reason = "NoSecretReference"
+		condition.Message = "Auth method does not reference a Kubernetes secret"
+		return condition
+	}
+
+	for i := range refs {
+		_, err := secretbackend.ResolveSecretReference(ctx, r.Client, &refs[i])
+		if err == nil {
+			continue
+		}
+
+		condition.Status = metav1.ConditionFalse
+		condition.Message = err.Error()
+		switch {
+		case errors.IsNotFound(err):
+			condition.Reason = "SecretNotFound"
+			condition.Message = fmt.Sprintf("Secret %s/%s not found", refs[i].Namespace, refs[i].Name)
+		case goerrors.Is(err, secretbackend.ErrSecretKeyNotFound):
+			condition.Reason = "SecretKeyNotFound"
+			condition.Message = fmt.Sprintf("Key %s not found in secret %s/%s", refs[i].Key, refs[i].Namespace, refs[i].Name)
+		default:
+			condition.Reason = "SecretReadFailed"
+		}
+		return condition
+	}
+
+	return condition
+}
+
+// secretRefField is the field index on the Kubernetes secrets a SecretBackendConfig
+// references, as namespace/name
+const secretRefField = "spec.secretRefs"
+
+// indexSecretRefs returns the secrets referenced by a SecretBackendConfig for the
+// secretRefField index
+func indexSecretRefs(obj client.Object) []string {
+	config, ok := obj.(*configv1alpha1.SecretBackendConfig)
+	if !ok {
+		return nil
+	}
+
+	var keys []string
+	for _, ref := range secretbackend.SecretReferences(config) {
+		keys = append(keys, ref.Namespace+"/"+ref.Name)
+	}
+	return keys
+}
+
+// findConfigsForSecret finds SecretBackendConfigs referencing the secret, either from
+// their auth method or as content hash key
+func (r *SecretBackendConfigReconciler) findConfigsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var configs configv1alpha1.SecretBackendConfigList
+	if err := r.List(ctx, &configs, client.MatchingFields{secretRefField: obj.GetNamespace() + "/" + obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list SecretBackendConfigs referencing the secret",
+			"secret", types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()})
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(configs.Items))
+	for _, config := range configs.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: config.Name},
+		})
+	}
+	return requests
 }
 
 // SetupWithManager sets up the controller with the Manager
 func (r *SecretBackendConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index configs by the secrets they reference so secret events don't list all configs
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &configv1alpha1.SecretBackendConfig{}, secretRefField, indexSecretRefs); err != nil {
+		return fmt.Errorf("failed to index SecretBackendConfigs by secret reference: %w", err)
+	}
+	if r.SecretMetadataReader == nil {
+		r.SecretMetadataReader = mgr.GetCache()
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&configv1alpha1.SecretBackendConfig{}).
+		// Status updates of the periodic probes must not trigger another probe
+		For(&configv1alpha1.SecretBackendConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Only the metadata of secrets is watched, their data is never cached
+		WatchesMetadata(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findConfigsForSecret),
+		).
 		Complete(r)
 }
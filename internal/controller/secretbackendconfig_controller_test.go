@@ -18,19 +18,26 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
+	_ "github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/vault"
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/vaulttest"
 )
 
 var _ = Describe("SecretBackendConfig Controller", func() {
@@ -47,6 +54,7 @@ var _ = Describe("SecretBackendConfig Controller", func() {
 		// Setup scheme
 		scheme = runtime.NewScheme()
 		Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
 
 		// Create fake recorder
 		recorder = record.NewFakeRecorder(100)
@@ -77,19 +85,20 @@ var _ = Describe("SecretBackendConfig Controller", func() {
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
 				WithObjects(backendConfig).
+				WithStatusSubresource(&configv1alpha1.SecretBackendConfig{}).
 				Build()
 
-			backendFactory, err := secretbackend.NewBackendFactory(k8sClient, nil)
+			backendRegistry, err := secretbackend.NewBackendRegistry(k8sClient, nil)
 			Expect(err).NotTo(HaveOccurred())
 			defer func() {
-				Expect(backendFactory.Close()).To(Succeed())
+				Expect(backendRegistry.Close()).To(Succeed())
 			}()
 
 			reconciler = &SecretBackendConfigReconciler{
-				Client:         k8sClient,
-				Scheme:         scheme,
-				Recorder:       recorder,
-				BackendFactory: backendFactory,
+				Client:   k8sClient,
+				Scheme:   scheme,
+				Recorder: recorder,
+				Registry: backendRegistry,
 			}
 
 			// First reconciliation loads config
@@ -107,17 +116,17 @@ var _ = Describe("SecretBackendConfig Controller", func() {
 				WithScheme(scheme).
 				Build()
 
-			backendFactory, err := secretbackend.NewBackendFactory(k8sClient, nil)
+			backendRegistry, err := secretbackend.NewBackendRegistry(k8sClient, nil)
 			Expect(err).NotTo(HaveOccurred())
 			defer func() {
-				Expect(backendFactory.Close()).To(Succeed())
+				Expect(backendRegistry.Close()).To(Succeed())
 			}()
 
 			reconciler = &SecretBackendConfigReconciler{
-				Client:         k8sClient,
-				Scheme:         scheme,
-				Recorder:       recorder,
-				BackendFactory: backendFactory,
+				Client:   k8sClient,
+				Scheme:   scheme,
+				Recorder: recorder,
+				Registry: backendRegistry,
 			}
 
 			// Reconcile for non-existent config (simulates deletion)
@@ -126,6 +135,74 @@ var _ = Describe("SecretBackendConfig Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("Should delete the sync statuses of a deleted config", func() {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(
+					&configv1alpha1.BMCSecretSyncStatus{
+						ObjectMeta: metav1.ObjectMeta{Name: "test-secret-vault-eu-sync-status"},
+						Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "test-secret", ConfigRef: "vault-eu"},
+					},
+					&configv1alpha1.BMCSecretSyncStatus{
+						ObjectMeta: metav1.ObjectMeta{Name: "test-secret-sync-status"},
+						Spec:       configv1alpha1.BMCSecretSyncStatusSpec{BMCSecretRef: "test-secret", ConfigRef: "default-backend-config"},
+					},
+				).
+				Build()
+
+			backendRegistry, err := secretbackend.NewBackendRegistry(k8sClient, nil)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backendRegistry.Close)
+
+			reconciler = &SecretBackendConfigReconciler{
+				Client:   k8sClient,
+				Scheme:   scheme,
+				Recorder: recorder,
+				Registry: backendRegistry,
+			}
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "vault-eu"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var syncStatuses configv1alpha1.BMCSecretSyncStatusList
+			Expect(k8sClient.List(ctx, &syncStatuses)).To(Succeed())
+			Expect(syncStatuses.Items).To(ConsistOf(HaveField("Name", "test-secret-sync-status")))
+		})
+
+		It("Should notify the BMCSecret controller without blocking", func() {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				Build()
+
+			backendRegistry, err := secretbackend.NewBackendRegistry(k8sClient, nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				Expect(backendRegistry.Close()).To(Succeed())
+			}()
+
+			configChanges := make(chan event.GenericEvent, 1)
+			reconciler = &SecretBackendConfigReconciler{
+				Client:        k8sClient,
+				Scheme:        scheme,
+				Recorder:      recorder,
+				Registry:      backendRegistry,
+				ConfigChanges: configChanges,
+			}
+
+			// The second change is coalesced with the pending notification
+			for range 2 {
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: "default-backend-config"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(configChanges).To(Receive(HaveField("Object.GetName()", "default-backend-config")))
+			Expect(configChanges).NotTo(Receive())
+		})
 	})
 
 	Context("Cache invalidation behavior", func() {
@@ -187,4 +264,301 @@ var _ = Describe("SecretBackendConfig Controller", func() {
 			Expect(regionKey).To(Equal("datacenter"))
 		})
 	})
+
+	Context("When using token auth", func() {
+		var backendConfig *configv1alpha1.SecretBackendConfig
+
+		BeforeEach(func() {
+			backendConfig = &configv1alpha1.SecretBackendConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default-backend-config",
+				},
+				Spec: configv1alpha1.SecretBackendConfigSpec{
+					Backend: "vault",
+					VaultConfig: &configv1alpha1.VaultConfig{
+						Address:    "https://vault.example.com:8200",
+						AuthMethod: "token",
+						TokenAuth: &configv1alpha1.TokenAuthConfig{
+							SecretRef: configv1alpha1.SecretReference{
+								Name:      "vault-token",
+								Namespace: "bmc-secret-operator-system",
+								Key:       "token",
+							},
+						},
+						MountPath: "secret",
+					},
+				},
+			}
+		})
+
+		newReconciler := func(objs ...client.Object) *SecretBackendConfigReconciler {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithIndex(&configv1alpha1.SecretBackendConfig{}, secretRefField, indexSecretRefs).
+				WithObjects(objs...).
+				WithStatusSubresource(&configv1alpha1.SecretBackendConfig{}).
+				Build()
+
+			backendRegistry, err := secretbackend.NewBackendRegistry(k8sClient, nil)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backendRegistry.Close)
+
+			return &SecretBackendConfigReconciler{
+				Client:   k8sClient,
+				Scheme:   scheme,
+				Recorder: recorder,
+				Registry: backendRegistry,
+			}
+		}
+
+		reconcileAndGetCondition := func(r *SecretBackendConfigReconciler) *metav1.Condition {
+			_, err := r.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "default-backend-config"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var updated configv1alpha1.SecretBackendConfig
+			Expect(r.Get(ctx, types.NamespacedName{Name: "default-backend-config"}, &updated)).To(Succeed())
+			return apimeta.FindStatusCondition(updated.Status.Conditions, "CredentialsAvailable")
+		}
+
+		It("Should report available credentials when the token secret exists", func() {
+			r := newReconciler(backendConfig, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "bmc-secret-operator-system"},
+				Data:       map[string][]byte{"token": []byte("hvs.token")},
+			})
+
+			condition := reconcileAndGetCondition(r)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("SecretResolved"))
+		})
+
+		It("Should report a missing token secret", func() {
+			r := newReconciler(backendConfig)
+
+			condition := reconcileAndGetCondition(r)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("SecretNotFound"))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("SecretNotFound")))
+		})
+
+		It("Should report a missing key in the token secret", func() {
+			r := newReconciler(backendConfig, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "bmc-secret-operator-system"},
+				Data:       map[string][]byte{"other": []byte("value")},
+			})
+
+			condition := reconcileAndGetCondition(r)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("SecretKeyNotFound"))
+		})
+
+		It("Should map the referenced secret to the config", func() {
+			r := newReconciler(backendConfig)
+
+			requests := r.findConfigsForSecret(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "bmc-secret-operator-system"},
+			})
+			Expect(requests).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "default-backend-config"},
+			}))
+
+			requests = r.findConfigsForSecret(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "bmc-secret-operator-system"},
+			})
+			Expect(requests).To(BeEmpty())
+		})
+
+		It("Should map the content hash key secret to the config", func() {
+			backendConfig.Spec.ContentHashKeyRef = &configv1alpha1.SecretReference{
+				Name:      "content-hash-key",
+				Namespace: "bmc-secret-operator-system",
+				Key:       "key",
+			}
+			r := newReconciler(backendConfig)
+
+			requests := r.findConfigsForSecret(ctx, &metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{Name: "content-hash-key", Namespace: "bmc-secret-operator-system"},
+			})
+			Expect(requests).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "default-backend-config"},
+			}))
+		})
+	})
+
+	Context("When probing the backends", func() {
+		var (
+			server        *vaulttest.Server
+			backendConfig *configv1alpha1.SecretBackendConfig
+		)
+
+		BeforeEach(func() {
+			server = vaulttest.NewServer()
+			server.AddMount("secret", 2)
+			server.AddMount("team-a", 2)
+			server.AddToken("hvs.token")
+			DeferCleanup(server.Close)
+
+			backendConfig = &configv1alpha1.SecretBackendConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "default-backend-config",
+					Generation: 1,
+				},
+				Spec: configv1alpha1.SecretBackendConfigSpec{
+					Backend: "vault",
+					VaultConfig: &configv1alpha1.VaultConfig{
+						Address:    server.URL,
+						AuthMethod: "token",
+						TokenAuth: &configv1alpha1.TokenAuthConfig{
+							SecretRef: configv1alpha1.SecretReference{
+								Name:      "vault-token",
+								Namespace: "bmc-secret-operator-system",
+								Key:       "token",
+							},
+						},
+						MountPath: "secret",
+						TLSConfig: &configv1alpha1.TLSConfig{CACert: server.CACert()},
+						SecretEngines: []configv1alpha1.SecretEngineConfig{{
+							Name:      "team-a",
+							MountPath: "team-a",
+							SyncLabel: "team-a.example.com/sync",
+						}},
+					},
+				},
+			}
+		})
+
+		newReconciler := func(objs ...client.Object) *SecretBackendConfigReconciler {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objs...).
+				WithStatusSubresource(&configv1alpha1.SecretBackendConfig{}).
+				Build()
+
+			backendRegistry, err := secretbackend.NewBackendRegistry(k8sClient, nil)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backendRegistry.Close)
+
+			return &SecretBackendConfigReconciler{
+				Client:        k8sClient,
+				Scheme:        scheme,
+				Recorder:      recorder,
+				Registry:      backendRegistry,
+				Prober:        secretbackend.NewBackendProber(k8sClient, nil),
+				ProbeInterval: time.Minute,
+			}
+		}
+
+		tokenSecret := func(token string) *corev1.Secret {
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "bmc-secret-operator-system"},
+				Data:       map[string][]byte{"token": []byte(token)},
+			}
+		}
+
+		reconcileAndGetStatus := func(r *SecretBackendConfigReconciler) configv1alpha1.SecretBackendConfigStatus {
+			result, err := r.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "default-backend-config"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+			var updated configv1alpha1.SecretBackendConfig
+			Expect(r.Get(ctx, types.NamespacedName{Name: "default-backend-config"}, &updated)).To(Succeed())
+			return updated.Status
+		}
+
+		expectCondition := func(status configv1alpha1.SecretBackendConfigStatus, conditionType string, conditionStatus metav1.ConditionStatus, reason string) {
+			condition := apimeta.FindStatusCondition(status.Conditions, conditionType)
+			ExpectWithOffset(1, condition).NotTo(BeNil())
+			ExpectWithOffset(1, condition.Status).To(Equal(conditionStatus))
+			ExpectWithOffset(1, condition.Reason).To(Equal(reason))
+		}
+
+		It("Should report a ready config when every backend can be used", func() {
+			r := newReconciler(backendConfig, tokenSecret("hvs.token"))
+
+			status := reconcileAndGetStatus(r)
+			expectCondition(status, "Ready", metav1.ConditionTrue, "BackendsReady")
+			expectCondition(status, "Authenticated", metav1.ConditionTrue, "LoginSucceeded")
+			expectCondition(status, "Degraded", metav1.ConditionFalse, "BackendsReady")
+			Expect(status.ObservedGeneration).To(Equal(int64(1)))
+			Expect(status.LastProbeTime).NotTo(BeNil())
+			Expect(status.Engines).To(ConsistOf(configv1alpha1.SecretEngineStatus{
+				Name:      "team-a",
+				MountPath: "team-a",
+				Ready:     true,
+				Reason:    "Ready",
+			}))
+		})
+
+		It("Should report a degraded config when the mount of an engine is missing", func() {
+			backendConfig.Spec.VaultConfig.SecretEngines[0].MountPath = "missing"
+			r := newReconciler(backendConfig, tokenSecret("hvs.token"))
+
+			status := reconcileAndGetStatus(r)
+			expectCondition(status, "Ready", metav1.ConditionFalse, "MountDetectionFailed")
+			expectCondition(status, "Authenticated", metav1.ConditionTrue, "LoginSucceeded")
+			expectCondition(status, "Degraded", metav1.ConditionTrue, "PartiallyAvailable")
+			Expect(status.Engines).To(HaveLen(1))
+			Expect(status.Engines[0].Ready).To(BeFalse())
+			Expect(status.Engines[0].Reason).To(Equal("MountDetectionFailed"))
+			Expect(status.Engines[0].Message).To(ContainSubstring("mount missing not found"))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("MountDetectionFailed")))
+		})
+
+		It("Should report failed authentication with a rejected token", func() {
+			r := newReconciler(backendConfig, tokenSecret("hvs.revoked"))
+
+			status := reconcileAndGetStatus(r)
+			expectCondition(status, "Ready", metav1.ConditionFalse, "AuthenticationFailed")
+			expectCondition(status, "Authenticated", metav1.ConditionFalse, "AuthenticationFailed")
+			expectCondition(status, "Degraded", metav1.ConditionFalse, "Unavailable")
+		})
+
+		It("Should report missing credentials of the auth method", func() {
+			r := newReconciler(backendConfig)
+
+			status := reconcileAndGetStatus(r)
+			expectCondition(status, "Ready", metav1.ConditionFalse, "CredentialsUnavailable")
+			expectCondition(status, "Authenticated", metav1.ConditionFalse, "CredentialsUnavailable")
+		})
+
+		It("Should report an invalid config without probing", func() {
+			backendConfig.Spec.PathTemplate = "bmc/{{.Hostname"
+			r := newReconciler(backendConfig, tokenSecret("hvs.token"))
+
+			status := reconcileAndGetStatus(r)
+			expectCondition(status, "Ready", metav1.ConditionFalse, "InvalidConfig")
+			expectCondition(status, "Authenticated", metav1.ConditionUnknown, "InvalidConfig")
+			expectCondition(status, "Degraded", metav1.ConditionFalse, "InvalidConfig")
+			Expect(status.Engines).To(BeEmpty())
+			Expect(server.Requests).To(BeEmpty())
+		})
+
+		It("Should not invalidate the cache again when probing an unchanged config", func() {
+			configChanges := make(chan event.GenericEvent, 10)
+			r := newReconciler(backendConfig, tokenSecret("hvs.token"))
+			r.ConfigChanges = configChanges
+
+			reconcileAndGetStatus(r)
+			Expect(configChanges).To(HaveLen(1))
+
+			reconcileAndGetStatus(r)
+			Expect(configChanges).To(HaveLen(1))
+
+			// Rotating the token applies to the cached backends
+			var secret corev1.Secret
+			Expect(r.Get(ctx, types.NamespacedName{Name: "vault-token", Namespace: "bmc-secret-operator-system"}, &secret)).To(Succeed())
+			secret.Data["token"] = []byte("hvs.rotated")
+			Expect(r.Update(ctx, &secret)).To(Succeed())
+			server.AddToken("hvs.rotated")
+
+			reconcileAndGetStatus(r)
+			Expect(configChanges).To(HaveLen(2))
+		})
+	})
 })
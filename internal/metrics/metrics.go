@@ -52,6 +52,8 @@ type Collector struct {
 	// Authentication metrics
 	backendAuthDuration *prometheus.HistogramVec
 	backendAuthTotal    *prometheus.CounterVec
+	tokenTTL            *prometheus.GaugeVec
+	tokenRenewalTotal   *prometheus.CounterVec
 
 	// Discovery metrics
 	bmcDiscoveryDuration *prometheus.HistogramVec
@@ -124,7 +126,7 @@ func NewCollector() *Collector {
 					Help:    "Duration of backend operations in seconds",
 					Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5},
 				},
-				[]string{"operation", "backend_type"},
+				[]string{"operation", "backend_type", "namespace"},
 			),
 
 			// Backend operation counts
@@ -133,7 +135,7 @@ func NewCollector() *Collector {
 					Name: "bmcsecret_backend_operation_total",
 					Help: "Total number of backend operations",
 				},
-				[]string{"operation", "backend_type", "result"},
+				[]string{"operation", "backend_type", "namespace", "result"},
 			),
 
 			// Backend errors by type
@@ -142,7 +144,7 @@ func NewCollector() *Collector {
 					Name: "bmcsecret_backend_errors_total",
 					Help: "Total number of backend errors by error type",
 				},
-				[]string{"operation", "backend_type", "error_type"},
+				[]string{"operation", "backend_type", "namespace", "error_type"},
 			),
 
 			// Authentication operation duration
@@ -152,7 +154,7 @@ func NewCollector() *Collector {
 					Help:    "Duration of backend authentication operations in seconds",
 					Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0},
 				},
-				[]string{"method", "backend_type"},
+				[]string{"method", "backend_type", "namespace"},
 			),
 
 			// Authentication attempt counts
@@ -161,7 +163,25 @@ func NewCollector() *Collector {
 					Name: "bmcsecret_backend_auth_total",
 					Help: "Total number of backend authentication attempts",
 				},
-				[]string{"method", "backend_type", "result"},
+				[]string{"method", "backend_type", "namespace", "result"},
+			),
+
+			// Remaining TTL of the backend token after login or renewal
+			tokenTTL: promauto.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "bmcsecret_backend_token_ttl_seconds",
+					Help: "Remaining TTL of the backend token after the last login or renewal in seconds",
+				},
+				[]string{"backend_type", "config", "engine", "namespace"},
+			),
+
+			// Token renewal attempt counts
+			tokenRenewalTotal: promauto.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "bmcsecret_backend_token_renewal_total",
+					Help: "Total number of backend token renewals",
+				},
+				[]string{"backend_type", "config", "engine", "namespace", "result"},
 			),
 
 			// BMC discovery duration
@@ -211,44 +231,60 @@ func (c *Collector) RecordSyncStatus(secret string, successPaths, failedPaths in
 	}
 }
 
-// RecordBackendOperation records a backend operation duration and result
-func (c *Collector) RecordBackendOperation(operation, backendType string, duration time.Duration, err error) {
-	c.backendOpDuration.WithLabelValues(operation, backendType).Observe(duration.Seconds())
+// RecordBackendOperation records a backend operation duration and result. The
+// namespace is empty for the root namespace.
+func (c *Collector) RecordBackendOperation(operation, backendType, namespace string, duration time.Duration, err error) {
+	c.backendOpDuration.WithLabelValues(operation, backendType, namespace).Observe(duration.Seconds())
 
 	result := resultSuccess
 	if err != nil {
 		result = resultError
-		c.recordBackendError(operation, backendType, err)
+		c.recordBackendError(operation, backendType, namespace, err)
 	}
-	c.backendOpTotal.WithLabelValues(operation, backendType, result).Inc()
+	c.backendOpTotal.WithLabelValues(operation, backendType, namespace, result).Inc()
 }
 
 // RecordBackendOperationWithEngine records a backend operation with engine label
-func (c *Collector) RecordBackendOperationWithEngine(operation, backendType, engine string, duration time.Duration, err error) {
+func (c *Collector) RecordBackendOperationWithEngine(operation, backendType, engine, namespace string, duration time.Duration, err error) {
 	// Use existing metrics but add engine as part of backend type for now
 	// This is backward compatible - we append engine name to backend type
 	backendLabel := backendType
 	if engine != "" {
 		backendLabel = backendType + ":" + engine
 	}
-	c.RecordBackendOperation(operation, backendLabel, duration, err)
+	c.RecordBackendOperation(operation, backendLabel, namespace, duration, err)
 }
 
 // recordBackendError records backend error details
-func (c *Collector) recordBackendError(operation, backendType string, err error) {
+func (c *Collector) recordBackendError(operation, backendType, namespace string, err error) {
 	errorType := classifyError(err)
-	c.backendErrorsTotal.WithLabelValues(operation, backendType, errorType).Inc()
+	c.backendErrorsTotal.WithLabelValues(operation, backendType, namespace, errorType).Inc()
+}
+
+// RecordAuth records authentication operation duration and result. The namespace
+// is empty for the root namespace.
+func (c *Collector) RecordAuth(method, backendType, namespace string, duration time.Duration, err error) {
+	c.backendAuthDuration.WithLabelValues(method, backendType, namespace).Observe(duration.Seconds())
+
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+	c.backendAuthTotal.WithLabelValues(method, backendType, namespace, result).Inc()
 }
 
-// RecordAuth records authentication operation duration and result
-func (c *Collector) RecordAuth(method, backendType string, duration time.Duration, err error) {
-	c.backendAuthDuration.WithLabelValues(method, backendType).Observe(duration.Seconds())
+// RecordTokenTTL records the remaining TTL of the token of a backend
+func (c *Collector) RecordTokenTTL(backendType, configName, engine, namespace string, ttl time.Duration) {
+	c.tokenTTL.WithLabelValues(backendType, configName, engine, namespace).Set(ttl.Seconds())
+}
 
+// RecordTokenRenewal records a token renewal result
+func (c *Collector) RecordTokenRenewal(backendType, configName, engine, namespace string, err error) {
 	result := resultSuccess
 	if err != nil {
 		result = resultError
 	}
-	c.backendAuthTotal.WithLabelValues(method, backendType, result).Inc()
+	c.tokenRenewalTotal.WithLabelValues(backendType, configName, engine, namespace, result).Inc()
 }
 
 // RecordBMCDiscovery records BMC discovery operation duration
@@ -273,6 +309,11 @@ func classifyError(err error) string {
 
 	errStr := err.Error()
 
+	// Check-and-set conflicts with a concurrent writer
+	if contains(errStr, "check-and-set") {
+		return "cas_conflict"
+	}
+
 	// Network/connectivity errors
 	if contains(errStr, "connection refused", "connection reset", "dial tcp", "no such host") {
 		return "network"
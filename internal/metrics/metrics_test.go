@@ -204,21 +204,21 @@ func TestRecordBackendOperation(t *testing.T) {
 				Name: "test_backend_operation_duration_seconds",
 				Help: "Test backend operation duration",
 			},
-			[]string{"operation", "backend_type"},
+			[]string{"operation", "backend_type", "namespace"},
 		),
 		backendOpTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "test_backend_operation_total",
 				Help: "Test backend operation total",
 			},
-			[]string{"operation", "backend_type", "result"},
+			[]string{"operation", "backend_type", "namespace", "result"},
 		),
 		backendErrorsTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "test_backend_errors_total",
 				Help: "Test backend errors total",
 			},
-			[]string{"operation", "backend_type", "error_type"},
+			[]string{"operation", "backend_type", "namespace", "error_type"},
 		),
 	}
 	reg.MustRegister(collector.backendOpDuration)
@@ -226,10 +226,10 @@ func TestRecordBackendOperation(t *testing.T) {
 	reg.MustRegister(collector.backendErrorsTotal)
 
 	// Record successful operation
-	collector.RecordBackendOperation("write", "vault", 100*time.Millisecond, nil)
+	collector.RecordBackendOperation("write", "vault", "", 100*time.Millisecond, nil)
 
 	// Record failed operation
-	collector.RecordBackendOperation("read", "vault", 50*time.Millisecond, errors.New("connection refused"))
+	collector.RecordBackendOperation("read", "vault", "admin/team-a", 50*time.Millisecond, errors.New("connection refused"))
 
 	// Verify metrics
 	if testutil.CollectAndCount(collector.backendOpDuration) == 0 {
@@ -251,24 +251,24 @@ func TestRecordAuth(t *testing.T) {
 				Name: "test_backend_auth_duration_seconds",
 				Help: "Test backend auth duration",
 			},
-			[]string{"method", "backend_type"},
+			[]string{"method", "backend_type", "namespace"},
 		),
 		backendAuthTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "test_backend_auth_total",
 				Help: "Test backend auth total",
 			},
-			[]string{"method", "backend_type", "result"},
+			[]string{"method", "backend_type", "namespace", "result"},
 		),
 	}
 	reg.MustRegister(collector.backendAuthDuration)
 	reg.MustRegister(collector.backendAuthTotal)
 
 	// Record successful auth
-	collector.RecordAuth("kubernetes", "vault", 200*time.Millisecond, nil)
+	collector.RecordAuth("kubernetes", "vault", "", 200*time.Millisecond, nil)
 
 	// Record failed auth
-	collector.RecordAuth("token", "vault", 100*time.Millisecond, errors.New("authentication failed"))
+	collector.RecordAuth("token", "vault", "admin/team-a", 100*time.Millisecond, errors.New("authentication failed"))
 
 	// Verify metrics
 	if testutil.CollectAndCount(collector.backendAuthDuration) == 0 {
@@ -279,6 +279,46 @@ func TestRecordAuth(t *testing.T) {
 	}
 }
 
+func TestRecordTokenLifecycle(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	collector := &Collector{
+		tokenTTL: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "test_backend_token_ttl_seconds",
+				Help: "Test backend token TTL",
+			},
+			[]string{"backend_type", "config", "engine", "namespace"},
+		),
+		tokenRenewalTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "test_backend_token_renewal_total",
+				Help: "Test backend token renewal total",
+			},
+			[]string{"backend_type", "config", "engine", "namespace", "result"},
+		),
+	}
+	reg.MustRegister(collector.tokenTTL)
+	reg.MustRegister(collector.tokenRenewalTotal)
+
+	collector.RecordTokenTTL("vault", "region-a", "", "", 30*time.Minute)
+	collector.RecordTokenTTL("vault", "region-a", "team-a", "team-a", 10*time.Minute)
+	collector.RecordTokenRenewal("vault", "region-a", "", "", nil)
+	collector.RecordTokenRenewal("vault", "region-a", "team-a", "team-a", errors.New("permission denied"))
+
+	if ttl := testutil.ToFloat64(collector.tokenTTL.WithLabelValues("vault", "region-a", "", "")); ttl != 1800 {
+		t.Errorf("Expected token TTL 1800, got %v", ttl)
+	}
+	if ttl := testutil.ToFloat64(collector.tokenTTL.WithLabelValues("vault", "region-a", "team-a", "team-a")); ttl != 600 {
+		t.Errorf("Expected engine token TTL 600, got %v", ttl)
+	}
+	if count := testutil.ToFloat64(collector.tokenRenewalTotal.WithLabelValues("vault", "region-a", "", "", "success")); count != 1 {
+		t.Errorf("Expected 1 successful renewal, got %v", count)
+	}
+	if count := testutil.ToFloat64(collector.tokenRenewalTotal.WithLabelValues("vault", "region-a", "team-a", "team-a", "error")); count != 1 {
+		t.Errorf("Expected 1 failed renewal, got %v", count)
+	}
+}
+
 func TestRecordBMCDiscovery(t *testing.T) {
 	reg := prometheus.NewRegistry()
 	collector := &Collector{
@@ -367,6 +407,11 @@ func TestClassifyError(t *testing.T) {
 			err:      errors.New("context deadline exceeded"),
 			expected: "timeout",
 		},
+		{
+			name:     "check-and-set conflict",
+			err:      errors.New("check-and-set mismatch: check-and-set parameter did not match the current version"),
+			expected: "cas_conflict",
+		},
 		{
 			name:     "config error",
 			err:      errors.New("invalid configuration: missing address"),
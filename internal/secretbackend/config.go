@@ -19,40 +19,229 @@ package secretbackend
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
 	defaultBackendType = "vault"
+	openBaoBackendType = "openbao"
+
+	// DefaultMaxConcurrentSyncs is the default number of paths synced in parallel per engine
+	DefaultMaxConcurrentSyncs = 10
+
+	// DefaultVerificationInterval is the default interval for reading synced secrets back
+	DefaultVerificationInterval = 24 * time.Hour
+
+	// DefaultPathTemplate is the path template used when none is configured
+	DefaultPathTemplate = "bmc/{{.Region}}/{{.Hostname}}/{{.Username}}"
+
+	// EnvConfigName is the configuration name recorded in ownership markers
+	// when the configuration is loaded from environment variables
+	EnvConfigName = "environment"
+)
+
+// Orphan policies for backend paths that are no longer desired
+const (
+	OrphanPolicyDelete = "Delete"
+	OrphanPolicyRetain = "Retain"
+	OrphanPolicyReport = "Report"
+)
+
+// Deletion policies for secrets removed from the backend
+const (
+	// DeletionPolicyRetain leaves the secret in the backend
+	DeletionPolicyRetain = "Retain"
+	// DeletionPolicySoftDelete deletes the latest KV v2 version, so it can be recovered
+	DeletionPolicySoftDelete = "SoftDelete"
+	// DeletionPolicyDestroy permanently removes all versions and the metadata
+	DeletionPolicyDestroy = "Destroy"
 )
 
 // Config holds the backend configuration
 type Config struct {
+	// Name is the name of the SecretBackendConfig the configuration was loaded from
+	Name string
+
 	Backend        string
 	VaultConfig    *VaultConfigInternal
 	OpenBaoConfig  *OpenBaoConfigInternal
 	PathTemplate   string
 	RegionLabelKey string
-	SyncLabel      string
+	OrphanPolicy   string
+
+	// SyncSelector selects the BMCSecrets to sync, all of them if not configured
+	SyncSelector labels.Selector
+
+	// ClusterID identifies this cluster in the ownership marker of written secrets
+	ClusterID string
+
+	// AdoptPolicy controls whether existing secrets without an ownership marker
+	// are overwritten and deleted (Adopt) or left alone (Refuse)
+	AdoptPolicy string
+
+	// DeletionPolicy controls how secrets are removed from the backend
+	// (Retain, SoftDelete or Destroy). Secret engines may override it.
+	DeletionPolicy string
+
+	// MaxConcurrentSyncs is the number of paths synced in parallel per engine
+	MaxConcurrentSyncs int
+
+	// VerificationInterval is how often synced secrets are read back and compared in full
+	VerificationInterval time.Duration
+
+	// ContentHashKeyRef references the key of the recorded content hashes, resolved
+	// into ContentHashKey when the configuration is loaded
+	ContentHashKeyRef *configv1alpha1.SecretReference
+	ContentHashKey    []byte
+
+	// SecretEngines holds the secret engines configured for the backend, none
+	// for configurations loaded from environment variables
+	SecretEngines []configv1alpha1.SecretEngineConfig
 }
 
 // VaultConfigInternal holds internal Vault configuration
 type VaultConfigInternal struct {
 	Address            string
+	Namespace          string
 	AuthMethod         string
 	KubernetesAuthRole string
 	KubernetesAuthPath string
 	Token              string
+	AppRoleRoleID      string
+	AppRoleSecretID    string
+	AppRolePath        string
 	MountPath          string
 	SkipVerify         bool
 	CACert             string
+
+	// TokenSecretRef references the Kubernetes secret holding the token;
+	// it is resolved into Token by the BackendFactory
+	TokenSecretRef *configv1alpha1.SecretReference
+
+	// AppRoleSecretIDRef references the Kubernetes secret holding the AppRole
+	// secret ID; it is resolved into AppRoleSecretID by the BackendFactory
+	AppRoleSecretIDRef *configv1alpha1.SecretReference
+
+	// Engines holds the configuration of the secret engines overriding the address,
+	// namespace, auth or TLS settings by engine name. Other engines use this configuration.
+	Engines map[string]*VaultConfigInternal
 }
 
 // OpenBaoConfigInternal holds internal OpenBao configuration
-type OpenBaoConfigInternal struct {
-	Address    string
-	AuthMethod string
+// OpenBao is API-compatible with Vault, so it shares the Vault field set
+type OpenBaoConfigInternal VaultConfigInternal
+
+// kvConfig returns the Vault-compatible configuration of the selected backend
+func (c *Config) kvConfig() *VaultConfigInternal {
+	if c.Backend == openBaoBackendType {
+		return (*VaultConfigInternal)(c.OpenBaoConfig)
+	}
+	return c.VaultConfig
+}
+
+// namespace returns the namespace of the selected backend, empty for the root namespace
+func (c *Config) namespace() string {
+	if kvConfig := c.kvConfig(); kvConfig != nil {
+		return kvConfig.Namespace
+	}
+	return ""
+}
+
+// location returns where the selected backend stores secrets
+func (c *Config) location() Location {
+	kvConfig := c.kvConfig()
+	if kvConfig == nil {
+		return Location{}
+	}
+	return Location{Address: kvConfig.Address, Namespace: kvConfig.Namespace, MountPath: kvConfig.MountPath}
+}
+
+// contentHashKey returns the key of the recorded content hashes, one only kept in
+// memory if none is configured
+func (c *Config) contentHashKey() []byte {
+	if len(c.ContentHashKey) > 0 {
+		return c.ContentHashKey
+	}
+	return processContentHashKey()
+}
+
+// SyncSelector converts a sync selector to a labels.Selector. The deprecated
+// sync label is parsed as a label selector in string form, so "key" keeps
+// matching any value and "key=value" an exact one. Without either, all
+// BMCSecrets are selected.
+func SyncSelector(selector *metav1.LabelSelector, syncLabel string) (labels.Selector, error) {
+	if selector != nil {
+		return metav1.LabelSelectorAsSelector(selector)
+	}
+	if syncLabel == "" {
+		return labels.Everything(), nil
+	}
+	return labels.Parse(syncLabel)
+}
+
+// SetDefaults fills in the defaults of all unset fields of a SecretBackendConfig,
+// the same ones the operator assumes when it loads the config
+func SetDefaults(crdConfig *configv1alpha1.SecretBackendConfig) {
+	spec := &crdConfig.Spec
+	if spec.PathTemplate == "" {
+		spec.PathTemplate = DefaultPathTemplate
+	}
+	if spec.RegionLabelKey == "" {
+		spec.RegionLabelKey = "region"
+	}
+	if spec.OrphanPolicy == "" {
+		spec.OrphanPolicy = OrphanPolicyDelete
+	}
+	if spec.AdoptPolicy == "" {
+		spec.AdoptPolicy = AdoptPolicyRefuse
+	}
+	if spec.DeletionPolicy == "" {
+		spec.DeletionPolicy = DeletionPolicyDestroy
+	}
+	if spec.MaxConcurrentSyncs <= 0 {
+		spec.MaxConcurrentSyncs = DefaultMaxConcurrentSyncs
+	}
+	if spec.VerificationInterval == nil {
+		spec.VerificationInterval = &metav1.Duration{Duration: DefaultVerificationInterval}
+	}
+
+	if spec.VaultConfig != nil {
+		setKVDefaults(spec.VaultConfig)
+	}
+	if spec.OpenBaoConfig != nil {
+		setKVDefaults((*configv1alpha1.VaultConfig)(spec.OpenBaoConfig))
+	}
+}
+
+// setKVDefaults fills in the defaults of a Vault-compatible config and its secret engines.
+// Engines are not defaulted beyond their auth paths, as they inherit unset fields.
+func setKVDefaults(kvCfg *configv1alpha1.VaultConfig) {
+	if kvCfg.AuthMethod == "" {
+		kvCfg.AuthMethod = "kubernetes"
+	}
+	if kvCfg.MountPath == "" {
+		kvCfg.MountPath = "secret"
+	}
+	setAuthPathDefaults(kvCfg.KubernetesAuth, kvCfg.AppRoleAuth)
+
+	for i := range kvCfg.SecretEngines {
+		setAuthPathDefaults(kvCfg.SecretEngines[i].KubernetesAuth, kvCfg.SecretEngines[i].AppRoleAuth)
+	}
+}
+
+// setAuthPathDefaults fills in the mount paths of the given auth methods
+func setAuthPathDefaults(kubernetesAuth *configv1alpha1.KubernetesAuthConfig, appRoleAuth *configv1alpha1.AppRoleAuthConfig) {
+	if kubernetesAuth != nil && kubernetesAuth.Path == "" {
+		kubernetesAuth.Path = "kubernetes"
+	}
+	if appRoleAuth != nil && appRoleAuth.Path == "" {
+		appRoleAuth.Path = "approle"
+	}
 }
 
 // LoadConfigFromCRD converts CRD config to internal config
@@ -61,60 +250,158 @@ func LoadConfigFromCRD(crdConfig *configv1alpha1.SecretBackendConfig) (*Config,
 		return nil, fmt.Errorf("SecretBackendConfig is nil")
 	}
 
+	// Apply the defaults to a copy, the config may be shared with the informer cache
+	defaulted := crdConfig.DeepCopy()
+	SetDefaults(defaulted)
+	spec := &defaulted.Spec
+
 	config := &Config{
-		Backend:        crdConfig.Spec.Backend,
-		PathTemplate:   crdConfig.Spec.PathTemplate,
-		RegionLabelKey: crdConfig.Spec.RegionLabelKey,
-		SyncLabel:      crdConfig.Spec.SyncLabel,
-	}
+		Name:           defaulted.Name,
+		Backend:        spec.Backend,
+		PathTemplate:   spec.PathTemplate,
+		RegionLabelKey: spec.RegionLabelKey,
+		OrphanPolicy:   spec.OrphanPolicy,
+		ClusterID:      spec.ClusterID,
+		AdoptPolicy:    spec.AdoptPolicy,
+		DeletionPolicy: spec.DeletionPolicy,
 
-	// Set defaults
-	if config.PathTemplate == "" {
-		config.PathTemplate = "bmc/{{.Region}}/{{.Hostname}}/{{.Username}}"
+		MaxConcurrentSyncs:   int(spec.MaxConcurrentSyncs),
+		VerificationInterval: spec.VerificationInterval.Duration,
+		ContentHashKeyRef:    spec.ContentHashKeyRef,
+		SecretEngines:        secretEngines(spec),
 	}
-	if config.RegionLabelKey == "" {
-		config.RegionLabelKey = "region"
+
+	syncSelector, err := SyncSelector(spec.SyncSelector, spec.SyncLabel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sync selector: %w", err)
 	}
+	config.SyncSelector = syncSelector
 
 	// Load Vault config
-	if crdConfig.Spec.VaultConfig != nil {
-		vaultCfg := crdConfig.Spec.VaultConfig
-		config.VaultConfig = &VaultConfigInternal{
-			Address:    vaultCfg.Address,
-			AuthMethod: vaultCfg.AuthMethod,
-			MountPath:  vaultCfg.MountPath,
-		}
+	if spec.VaultConfig != nil {
+		config.VaultConfig = loadKVConfigFromCRD(spec.VaultConfig)
+	}
+
+	// Load OpenBao config (OpenBaoConfig mirrors VaultConfig field for field)
+	if spec.OpenBaoConfig != nil {
+		openBaoCfg := configv1alpha1.VaultConfig(*spec.OpenBaoConfig)
+		config.OpenBaoConfig = (*OpenBaoConfigInternal)(loadKVConfigFromCRD(&openBaoCfg))
+	}
+
+	return config, nil
+}
 
-		if config.VaultConfig.AuthMethod == "" {
-			config.VaultConfig.AuthMethod = "kubernetes"
+// loadKVConfigFromCRD converts a Vault-compatible CRD config to internal config
+func loadKVConfigFromCRD(kvCfg *configv1alpha1.VaultConfig) *VaultConfigInternal {
+	config := &VaultConfigInternal{
+		Address:    kvCfg.Address,
+		Namespace:  kvCfg.Namespace,
+		AuthMethod: kvCfg.AuthMethod,
+		MountPath:  kvCfg.MountPath,
+	}
+
+	config.setAuth(kvCfg.KubernetesAuth, kvCfg.TokenAuth, kvCfg.AppRoleAuth)
+	config.setTLS(kvCfg.TLSConfig)
+
+	for i := range kvCfg.SecretEngines {
+		engine := &kvCfg.SecretEngines[i]
+		if !overridesConnection(engine) {
+			continue
 		}
-		if config.VaultConfig.MountPath == "" {
-			config.VaultConfig.MountPath = "secret"
+		if config.Engines == nil {
+			config.Engines = make(map[string]*VaultConfigInternal)
 		}
+		config.Engines[engine.Name] = engineKVConfig(config, engine)
+	}
 
-		if vaultCfg.KubernetesAuth != nil {
-			config.VaultConfig.KubernetesAuthRole = vaultCfg.KubernetesAuth.Role
-			config.VaultConfig.KubernetesAuthPath = vaultCfg.KubernetesAuth.Path
-			if config.VaultConfig.KubernetesAuthPath == "" {
-				config.VaultConfig.KubernetesAuthPath = "kubernetes"
-			}
-		}
+	return config
+}
 
-		if vaultCfg.TLSConfig != nil {
-			config.VaultConfig.SkipVerify = vaultCfg.TLSConfig.SkipVerify
-			config.VaultConfig.CACert = vaultCfg.TLSConfig.CACert
-		}
+// engineKVConfig returns the configuration of a secret engine: the base configuration
+// with the address, namespace, auth and TLS settings the engine overrides. Credentials are
+// resolved separately for every engine, so each engine authenticates on its own.
+func engineKVConfig(base *VaultConfigInternal, engine *configv1alpha1.SecretEngineConfig) *VaultConfigInternal {
+	config := *base
+	config.MountPath = engine.MountPath
+	config.Engines = nil
+
+	if engine.Address != "" {
+		config.Address = engine.Address
 	}
+	if engine.Namespace != "" {
+		config.Namespace = engine.Namespace
+	}
+	if engine.AuthMethod != "" {
+		config.AuthMethod = engine.AuthMethod
+	}
+	config.setAuth(engine.KubernetesAuth, engine.TokenAuth, engine.AppRoleAuth)
+	config.setTLS(engine.TLSConfig)
 
-	// Load OpenBao config
-	if crdConfig.Spec.OpenBaoConfig != nil {
-		config.OpenBaoConfig = &OpenBaoConfigInternal{
-			Address:    crdConfig.Spec.OpenBaoConfig.Address,
-			AuthMethod: crdConfig.Spec.OpenBaoConfig.AuthMethod,
-		}
+	return &config
+}
+
+// overridesConnection reports whether a secret engine overrides the address,
+// namespace, auth or TLS settings of its SecretBackendConfig
+func overridesConnection(engine *configv1alpha1.SecretEngineConfig) bool {
+	return engine.Address != "" || engine.Namespace != "" || engine.AuthMethod != "" ||
+		engine.KubernetesAuth != nil || engine.TokenAuth != nil || engine.AppRoleAuth != nil ||
+		engine.TLSConfig != nil
+}
+
+// setAuth applies the given auth configurations, leaving the ones not given unchanged
+func (c *VaultConfigInternal) setAuth(
+	kubernetesAuth *configv1alpha1.KubernetesAuthConfig,
+	tokenAuth *configv1alpha1.TokenAuthConfig,
+	appRoleAuth *configv1alpha1.AppRoleAuthConfig,
+) {
+	if kubernetesAuth != nil {
+		c.KubernetesAuthRole = kubernetesAuth.Role
+		c.KubernetesAuthPath = kubernetesAuth.Path
 	}
 
-	return config, nil
+	if tokenAuth != nil {
+		tokenRef := tokenAuth.SecretRef
+		c.TokenSecretRef = &tokenRef
+		c.Token = ""
+	}
+
+	if appRoleAuth != nil {
+		c.AppRoleRoleID = appRoleAuth.RoleID
+		c.AppRolePath = appRoleAuth.Path
+		secretIDRef := appRoleAuth.SecretIDRef
+		c.AppRoleSecretIDRef = &secretIDRef
+		c.AppRoleSecretID = ""
+	}
+}
+
+// setTLS applies the given TLS configuration, leaving the current one unchanged if not given
+func (c *VaultConfigInternal) setTLS(tlsConfig *configv1alpha1.TLSConfig) {
+	if tlsConfig != nil {
+		c.SkipVerify = tlsConfig.SkipVerify
+		c.CACert = tlsConfig.CACert
+	}
+}
+
+// engineConfig returns the configuration used by the named secret engine
+func (c *VaultConfigInternal) engineConfig(engineName string) *VaultConfigInternal {
+	if engineConfig, ok := c.Engines[engineName]; ok {
+		return engineConfig
+	}
+	return c
+}
+
+// secretEngines returns the secret engines configured for the selected backend
+func secretEngines(spec *configv1alpha1.SecretBackendConfigSpec) []configv1alpha1.SecretEngineConfig {
+	if spec.Backend == openBaoBackendType {
+		if spec.OpenBaoConfig != nil {
+			return spec.OpenBaoConfig.SecretEngines
+		}
+		return nil
+	}
+	if spec.VaultConfig != nil {
+		return spec.VaultConfig.SecretEngines
+	}
+	return nil
 }
 
 // LoadConfigFromEnv loads configuration from environment variables
@@ -125,20 +412,75 @@ func LoadConfigFromEnv() (*Config, error) {
 	}
 
 	config := &Config{
+		Name:           EnvConfigName,
 		Backend:        backend,
-		PathTemplate:   getEnvOrDefault("PATH_TEMPLATE", "bmc/{{.Region}}/{{.Hostname}}/{{.Username}}"),
+		PathTemplate:   getEnvOrDefault("PATH_TEMPLATE", DefaultPathTemplate),
 		RegionLabelKey: getEnvOrDefault("REGION_LABEL_KEY", "region"),
-		SyncLabel:      os.Getenv("SYNC_LABEL"),
+		OrphanPolicy:   getEnvOrDefault("ORPHAN_POLICY", OrphanPolicyDelete),
+		ClusterID:      os.Getenv("CLUSTER_ID"),
+		AdoptPolicy:    getEnvOrDefault("ADOPT_POLICY", AdoptPolicyRefuse),
+		DeletionPolicy: getEnvOrDefault("DELETION_POLICY", DeletionPolicyDestroy),
+
+		MaxConcurrentSyncs:   DefaultMaxConcurrentSyncs,
+		VerificationInterval: DefaultVerificationInterval,
+	}
+
+	if key := os.Getenv("CONTENT_HASH_KEY"); key != "" {
+		config.ContentHashKey = []byte(key)
+	}
+
+	syncSelector, err := SyncSelector(nil, os.Getenv("SYNC_LABEL"))
+	if err != nil {
+		return nil, fmt.Errorf("SYNC_LABEL must be a valid label selector: %w", err)
+	}
+	config.SyncSelector = syncSelector
+
+	switch config.OrphanPolicy {
+	case OrphanPolicyDelete, OrphanPolicyRetain, OrphanPolicyReport:
+	default:
+		return nil, fmt.Errorf("ORPHAN_POLICY must be %s, %s or %s, got %q",
+			OrphanPolicyDelete, OrphanPolicyRetain, OrphanPolicyReport, config.OrphanPolicy)
+	}
+
+	if config.AdoptPolicy != AdoptPolicyRefuse && config.AdoptPolicy != AdoptPolicyAdopt {
+		return nil, fmt.Errorf("ADOPT_POLICY must be %s or %s, got %q", AdoptPolicyRefuse, AdoptPolicyAdopt, config.AdoptPolicy)
+	}
+
+	switch config.DeletionPolicy {
+	case DeletionPolicyRetain, DeletionPolicySoftDelete, DeletionPolicyDestroy:
+	default:
+		return nil, fmt.Errorf("DELETION_POLICY must be %s, %s or %s, got %q",
+			DeletionPolicyRetain, DeletionPolicySoftDelete, DeletionPolicyDestroy, config.DeletionPolicy)
+	}
+
+	if value := os.Getenv("MAX_CONCURRENT_SYNCS"); value != "" {
+		maxConcurrentSyncs, err := strconv.Atoi(value)
+		if err != nil || maxConcurrentSyncs < 1 {
+			return nil, fmt.Errorf("MAX_CONCURRENT_SYNCS must be a positive integer, got %q", value)
+		}
+		config.MaxConcurrentSyncs = maxConcurrentSyncs
+	}
+
+	if value := os.Getenv("VERIFICATION_INTERVAL"); value != "" {
+		verificationInterval, err := time.ParseDuration(value)
+		if err != nil || verificationInterval < 0 {
+			return nil, fmt.Errorf("VERIFICATION_INTERVAL must be a non-negative duration, got %q", value)
+		}
+		config.VerificationInterval = verificationInterval
 	}
 
 	switch backend {
 	case defaultBackendType:
 		config.VaultConfig = &VaultConfigInternal{
 			Address:            os.Getenv("VAULT_ADDR"),
+			Namespace:          os.Getenv("VAULT_NAMESPACE"),
 			AuthMethod:         getEnvOrDefault("VAULT_AUTH_METHOD", "kubernetes"),
 			KubernetesAuthRole: os.Getenv("VAULT_ROLE"),
 			KubernetesAuthPath: getEnvOrDefault("VAULT_KUBERNETES_PATH", "kubernetes"),
 			Token:              os.Getenv("VAULT_TOKEN"),
+			AppRoleRoleID:      os.Getenv("VAULT_APPROLE_ROLE_ID"),
+			AppRoleSecretID:    os.Getenv("VAULT_APPROLE_SECRET_ID"),
+			AppRolePath:        getEnvOrDefault("VAULT_APPROLE_PATH", "approle"),
 			MountPath:          getEnvOrDefault("VAULT_MOUNT_PATH", "secret"),
 			SkipVerify:         os.Getenv("VAULT_SKIP_VERIFY") == "true",
 		}
@@ -147,8 +489,24 @@ func LoadConfigFromEnv() (*Config, error) {
 			return nil, fmt.Errorf("VAULT_ADDR environment variable is required")
 		}
 
-	case "openbao":
-		return nil, fmt.Errorf("OpenBao backend not yet implemented")
+	case openBaoBackendType:
+		config.OpenBaoConfig = &OpenBaoConfigInternal{
+			Address:            os.Getenv("BAO_ADDR"),
+			Namespace:          os.Getenv("BAO_NAMESPACE"),
+			AuthMethod:         getEnvOrDefault("BAO_AUTH_METHOD", "kubernetes"),
+			KubernetesAuthRole: os.Getenv("BAO_ROLE"),
+			KubernetesAuthPath: getEnvOrDefault("BAO_KUBERNETES_PATH", "kubernetes"),
+			Token:              os.Getenv("BAO_TOKEN"),
+			AppRoleRoleID:      os.Getenv("BAO_APPROLE_ROLE_ID"),
+			AppRoleSecretID:    os.Getenv("BAO_APPROLE_SECRET_ID"),
+			AppRolePath:        getEnvOrDefault("BAO_APPROLE_PATH", "approle"),
+			MountPath:          getEnvOrDefault("BAO_MOUNT_PATH", "secret"),
+			SkipVerify:         os.Getenv("BAO_SKIP_VERIFY") == "true",
+		}
+
+		if config.OpenBaoConfig.Address == "" {
+			return nil, fmt.Errorf("BAO_ADDR environment variable is required")
+		}
 
 	default:
 		return nil, fmt.Errorf("unsupported backend type: %s", backend)
@@ -0,0 +1,796 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend
+
+import (
+	"context"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Config", func() {
+	Context("When loading an OpenBao config from the CRD", func() {
+		It("Should apply the same defaults as for Vault", func() {
+			config, err := LoadConfigFromCRD(&configv1alpha1.SecretBackendConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: DefaultBackendConfigName},
+				Spec: configv1alpha1.SecretBackendConfigSpec{
+					Backend: "openbao",
+					OpenBaoConfig: &configv1alpha1.OpenBaoConfig{
+						Address: "https://openbao.example.com:8200",
+						KubernetesAuth: &configv1alpha1.KubernetesAuthConfig{
+							Role: "bmc-operator",
+						},
+						TLSConfig: &configv1alpha1.TLSConfig{
+							CACert: "ca-pem",
+						},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.VaultConfig).To(BeNil())
+			Expect(config.OpenBaoConfig).NotTo(BeNil())
+			Expect(config.OpenBaoConfig.Address).To(Equal("https://openbao.example.com:8200"))
+			Expect(config.OpenBaoConfig.AuthMethod).To(Equal("kubernetes"))
+			Expect(config.OpenBaoConfig.KubernetesAuthRole).To(Equal("bmc-operator"))
+			Expect(config.OpenBaoConfig.KubernetesAuthPath).To(Equal("kubernetes"))
+			Expect(config.OpenBaoConfig.MountPath).To(Equal("secret"))
+			Expect(config.OpenBaoConfig.CACert).To(Equal("ca-pem"))
+			Expect(config.kvConfig()).To(Equal((*VaultConfigInternal)(config.OpenBaoConfig)))
+			Expect(config.OrphanPolicy).To(Equal(OrphanPolicyDelete))
+			Expect(config.AdoptPolicy).To(Equal(AdoptPolicyRefuse))
+			Expect(config.DeletionPolicy).To(Equal(DeletionPolicyDestroy))
+			Expect(config.Name).To(Equal(DefaultBackendConfigName))
+			Expect(config.MaxConcurrentSyncs).To(Equal(DefaultMaxConcurrentSyncs))
+			Expect(config.VerificationInterval).To(Equal(DefaultVerificationInterval))
+		})
+
+		It("Should return the secret engines of the selected backend", func() {
+			spec := &configv1alpha1.SecretBackendConfigSpec{
+				Backend: "openbao",
+				VaultConfig: &configv1alpha1.VaultConfig{
+					SecretEngines: []configv1alpha1.SecretEngineConfig{{Name: "vault-team"}},
+				},
+				OpenBaoConfig: &configv1alpha1.OpenBaoConfig{
+					SecretEngines: []configv1alpha1.SecretEngineConfig{{Name: "bao-team"}},
+				},
+			}
+			Expect(secretEngines(spec)).To(ConsistOf(HaveField("Name", "bao-team")))
+
+			spec.Backend = "vault"
+			Expect(secretEngines(spec)).To(ConsistOf(HaveField("Name", "vault-team")))
+		})
+	})
+
+	Context("When selecting BMCSecrets to sync", func() {
+		It("Should select all BMCSecrets without a selector or label", func() {
+			selector, err := SyncSelector(nil, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector.Matches(labels.Set{})).To(BeTrue())
+		})
+
+		It("Should keep the meaning of the deprecated sync label", func() {
+			selector, err := SyncSelector(nil, "sync")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector.Matches(labels.Set{"sync": "true"})).To(BeTrue())
+			Expect(selector.Matches(labels.Set{"team": "a"})).To(BeFalse())
+
+			selector, err = SyncSelector(nil, "team=a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector.Matches(labels.Set{"team": "a"})).To(BeTrue())
+			Expect(selector.Matches(labels.Set{"team": "b"})).To(BeFalse())
+		})
+
+		It("Should support set-based expressions", func() {
+			selector, err := SyncSelector(&metav1.LabelSelector{
+				MatchLabels: map[string]string{"sync": "true"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}},
+					{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"lab"}},
+					{Key: "region", Operator: metav1.LabelSelectorOpExists},
+					{Key: "decommissioned", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			}, "ignored")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(selector.Matches(labels.Set{"sync": "true", "team": "b", "env": "prod", "region": "eu"})).To(BeTrue())
+			Expect(selector.Matches(labels.Set{"sync": "true", "team": "c", "env": "prod", "region": "eu"})).To(BeFalse())
+			Expect(selector.Matches(labels.Set{"sync": "true", "team": "a", "env": "lab", "region": "eu"})).To(BeFalse())
+			Expect(selector.Matches(labels.Set{"sync": "true", "team": "a", "region": "eu", "decommissioned": "true"})).To(BeFalse())
+			Expect(selector.Matches(labels.Set{"team": "a", "region": "eu"})).To(BeFalse())
+		})
+
+		It("Should reject invalid selectors", func() {
+			_, err := SyncSelector(&metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpIn},
+				},
+			}, "")
+			Expect(err).To(HaveOccurred())
+
+			_, err = LoadConfigFromCRD(&configv1alpha1.SecretBackendConfig{
+				Spec: configv1alpha1.SecretBackendConfigSpec{Backend: "vault", SyncLabel: "team in (a"},
+			})
+			Expect(err).To(MatchError(ContainSubstring("invalid sync selector")))
+		})
+
+		It("Should require a selector for every secret engine", func() {
+			_, err := newEngineBackend(context.Background(), nil, &Config{Name: "default", Backend: "vault"}, &VaultConfigInternal{},
+				&configv1alpha1.SecretEngineConfig{Name: "team-a", MountPath: "team-a"}, nil)
+			Expect(err).To(MatchError("secret engine team-a: requires a syncSelector or syncLabel"))
+		})
+	})
+
+	Context("When loading an OpenBao config from the environment", func() {
+		BeforeEach(func() {
+			DeferCleanup(os.Unsetenv, "SECRET_BACKEND_TYPE")
+			DeferCleanup(os.Unsetenv, "BAO_ADDR")
+			DeferCleanup(os.Unsetenv, "BAO_TOKEN")
+			Expect(os.Setenv("SECRET_BACKEND_TYPE", "openbao")).To(Succeed())
+		})
+
+		It("Should read the BAO_* variables", func() {
+			Expect(os.Setenv("BAO_ADDR", "https://openbao.example.com:8200")).To(Succeed())
+			Expect(os.Setenv("BAO_TOKEN", "s.token")).To(Succeed())
+
+			config, err := LoadConfigFromEnv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Backend).To(Equal("openbao"))
+			Expect(config.OpenBaoConfig.Address).To(Equal("https://openbao.example.com:8200"))
+			Expect(config.OpenBaoConfig.Token).To(Equal("s.token"))
+			Expect(config.OpenBaoConfig.MountPath).To(Equal("secret"))
+		})
+
+		It("Should require BAO_ADDR", func() {
+			_, err := LoadConfigFromEnv()
+			Expect(err).To(MatchError(ContainSubstring("BAO_ADDR")))
+		})
+
+		It("Should reject an invalid MAX_CONCURRENT_SYNCS", func() {
+			DeferCleanup(os.Unsetenv, "MAX_CONCURRENT_SYNCS")
+			Expect(os.Setenv("BAO_ADDR", "https://openbao.example.com:8200")).To(Succeed())
+			Expect(os.Setenv("MAX_CONCURRENT_SYNCS", "0")).To(Succeed())
+
+			_, err := LoadConfigFromEnv()
+			Expect(err).To(MatchError(ContainSubstring("MAX_CONCURRENT_SYNCS")))
+		})
+
+		It("Should reject an invalid DELETION_POLICY", func() {
+			DeferCleanup(os.Unsetenv, "DELETION_POLICY")
+			Expect(os.Setenv("BAO_ADDR", "https://openbao.example.com:8200")).To(Succeed())
+			Expect(os.Setenv("DELETION_POLICY", "Purge")).To(Succeed())
+
+			_, err := LoadConfigFromEnv()
+			Expect(err).To(MatchError(ContainSubstring("DELETION_POLICY")))
+		})
+
+		It("Should reject an invalid ORPHAN_POLICY", func() {
+			DeferCleanup(os.Unsetenv, "ORPHAN_POLICY")
+			Expect(os.Setenv("BAO_ADDR", "https://openbao.example.com:8200")).To(Succeed())
+			Expect(os.Setenv("ORPHAN_POLICY", "retain")).To(Succeed())
+
+			_, err := LoadConfigFromEnv()
+			Expect(err).To(MatchError(ContainSubstring("ORPHAN_POLICY")))
+		})
+
+		It("Should reject an invalid ADOPT_POLICY", func() {
+			DeferCleanup(os.Unsetenv, "ADOPT_POLICY")
+			Expect(os.Setenv("BAO_ADDR", "https://openbao.example.com:8200")).To(Succeed())
+			Expect(os.Setenv("ADOPT_POLICY", "Overwrite")).To(Succeed())
+
+			_, err := LoadConfigFromEnv()
+			Expect(err).To(MatchError(ContainSubstring("ADOPT_POLICY")))
+		})
+	})
+
+	Context("When using AppRole authentication", func() {
+		var backendConfig *configv1alpha1.SecretBackendConfig
+
+		BeforeEach(func() {
+			backendConfig = &configv1alpha1.SecretBackendConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: DefaultBackendConfigName},
+				Spec: configv1alpha1.SecretBackendConfigSpec{
+					Backend: "vault",
+					VaultConfig: &configv1alpha1.VaultConfig{
+						Address:    "https://vault.example.com:8200",
+						AuthMethod: "approle",
+						AppRoleAuth: &configv1alpha1.AppRoleAuthConfig{
+							RoleID: "bmc-operator",
+							SecretIDRef: configv1alpha1.SecretReference{
+								Name:      "vault-approle",
+								Namespace: "bmc-secret-operator-system",
+								Key:       "secret-id",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		newFactory := func(objs ...runtime.Object) *BackendFactory {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+			factory, err := NewBackendFactory(fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(objs...).
+				Build(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			return factory
+		}
+
+		It("Should default the AppRole mount path", func() {
+			config, err := LoadConfigFromCRD(backendConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.VaultConfig.AppRoleRoleID).To(Equal("bmc-operator"))
+			Expect(config.VaultConfig.AppRolePath).To(Equal("approle"))
+		})
+
+		It("Should resolve the secret ID from the referenced secret", func() {
+			factory := newFactory(backendConfig, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault-approle", Namespace: "bmc-secret-operator-system"},
+				Data:       map[string][]byte{"secret-id": []byte("s3cr3t")},
+			})
+
+			config, err := factory.loadConfig(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			resolved, err := withAuthSecret(context.Background(), factory.client, config.VaultConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved.AppRoleSecretID).To(Equal("s3cr3t"))
+			Expect(config.VaultConfig.AppRoleSecretID).To(BeEmpty())
+		})
+
+		It("Should fail to create the backend when the referenced secret does not exist", func() {
+			factory := newFactory(backendConfig)
+
+			_, err := factory.loadConfig(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = factory.GetBackend(context.Background())
+			Expect(err).To(MatchError(ErrCredentialsUnavailable))
+			Expect(err).To(MatchError(ContainSubstring("failed to resolve approle auth secret")))
+		})
+
+		It("Should resolve the content hash key from the referenced secret", func() {
+			backendConfig.Spec.ContentHashKeyRef = &configv1alpha1.SecretReference{
+				Name:      "content-hash-key",
+				Namespace: "bmc-secret-operator-system",
+				Key:       "key",
+			}
+			factory := newFactory(backendConfig, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault-approle", Namespace: "bmc-secret-operator-system"},
+				Data:       map[string][]byte{"secret-id": []byte("s3cr3t")},
+			}, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "content-hash-key", Namespace: "bmc-secret-operator-system"},
+				Data:       map[string][]byte{"key": []byte("hash-key")},
+			})
+
+			key, err := factory.GetContentHashKey(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal([]byte("hash-key")))
+			Expect(SecretReferences(backendConfig)).To(ConsistOf(
+				HaveField("Name", "vault-approle"),
+				HaveField("Name", "content-hash-key"),
+			))
+		})
+
+		It("Should fall back to a key kept in memory without a content hash key", func() {
+			factory := newFactory(backendConfig, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault-approle", Namespace: "bmc-secret-operator-system"},
+				Data:       map[string][]byte{"secret-id": []byte("s3cr3t")},
+			})
+
+			key, err := factory.GetContentHashKey(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(HaveLen(contentHashKeySize))
+			Expect(key).To(Equal(processContentHashKey()))
+		})
+	})
+
+	Context("When using token authentication", func() {
+		It("Should resolve the token from the referenced secret", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			factory, err := NewBackendFactory(fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(&configv1alpha1.SecretBackendConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: DefaultBackendConfigName},
+					Spec: configv1alpha1.SecretBackendConfigSpec{
+						Backend: "vault",
+						VaultConfig: &configv1alpha1.VaultConfig{
+							Address:    "https://vault.example.com:8200",
+							AuthMethod: "token",
+							TokenAuth: &configv1alpha1.TokenAuthConfig{
+								SecretRef: configv1alpha1.SecretReference{
+									Name:      "vault-token",
+									Namespace: "bmc-secret-operator-system",
+									Key:       "token",
+								},
+							},
+						},
+					},
+				}, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "bmc-secret-operator-system"},
+					Data:       map[string][]byte{"token": []byte("hvs.token")},
+				}).
+				Build(), nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			config, err := factory.loadConfig(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			resolved, err := withAuthSecret(context.Background(), factory.client, config.VaultConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved.Token).To(Equal("hvs.token"))
+		})
+	})
+
+	Context("When secret engines override the connection settings", func() {
+		var backendConfig *configv1alpha1.SecretBackendConfig
+
+		BeforeEach(func() {
+			backendConfig = &configv1alpha1.SecretBackendConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: DefaultBackendConfigName},
+				Spec: configv1alpha1.SecretBackendConfigSpec{
+					Backend: "vault",
+					VaultConfig: &configv1alpha1.VaultConfig{
+						Address:    "https://vault.example.com:8200",
+						AuthMethod: "token",
+						TokenAuth: &configv1alpha1.TokenAuthConfig{
+							SecretRef: configv1alpha1.SecretReference{
+								Name:      "vault-token",
+								Namespace: "bmc-secret-operator-system",
+								Key:       "token",
+							},
+						},
+						TLSConfig: &configv1alpha1.TLSConfig{CACert: "base-ca"},
+						SecretEngines: []configv1alpha1.SecretEngineConfig{
+							{Name: "shared", MountPath: "shared", SyncLabel: "shared"},
+							{
+								Name:       "team-a",
+								MountPath:  "team-a",
+								SyncLabel:  "team=a",
+								Address:    "https://vault-a.example.com:8200",
+								AuthMethod: "kubernetes",
+								KubernetesAuth: &configv1alpha1.KubernetesAuthConfig{
+									Role: "team-a",
+								},
+							},
+							{
+								Name:      "team-b",
+								MountPath: "team-b",
+								SyncLabel: "team=b",
+								TokenAuth: &configv1alpha1.TokenAuthConfig{
+									SecretRef: configv1alpha1.SecretReference{
+										Name:      "vault-token-b",
+										Namespace: "bmc-secret-operator-system",
+										Key:       "token",
+									},
+								},
+								TLSConfig: &configv1alpha1.TLSConfig{SkipVerify: true},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("Should fall back to the base settings for everything not overridden", func() {
+			config, err := LoadConfigFromCRD(backendConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(config.VaultConfig.engineConfig("shared")).To(BeIdenticalTo(config.VaultConfig))
+
+			teamA := config.VaultConfig.engineConfig("team-a")
+			Expect(teamA.Address).To(Equal("https://vault-a.example.com:8200"))
+			Expect(teamA.AuthMethod).To(Equal("kubernetes"))
+			Expect(teamA.KubernetesAuthRole).To(Equal("team-a"))
+			Expect(teamA.KubernetesAuthPath).To(Equal("kubernetes"))
+			Expect(teamA.CACert).To(Equal("base-ca"))
+
+			teamB := config.VaultConfig.engineConfig("team-b")
+			Expect(teamB.Address).To(Equal("https://vault.example.com:8200"))
+			Expect(teamB.AuthMethod).To(Equal("token"))
+			Expect(teamB.TokenSecretRef.Name).To(Equal("vault-token-b"))
+			Expect(teamB.SkipVerify).To(BeTrue())
+			Expect(teamB.CACert).To(BeEmpty())
+		})
+
+		It("Should inherit the namespace unless an engine overrides it", func() {
+			backendConfig.Spec.VaultConfig.Namespace = "admin"
+			backendConfig.Spec.VaultConfig.SecretEngines = append(backendConfig.Spec.VaultConfig.SecretEngines,
+				configv1alpha1.SecretEngineConfig{Name: "team-c", MountPath: "team-c", SyncLabel: "team=c", Namespace: "admin/team-c"})
+
+			config, err := LoadConfigFromCRD(backendConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(config.VaultConfig.Namespace).To(Equal("admin"))
+			Expect(config.VaultConfig.engineConfig("team-a").Namespace).To(Equal("admin"))
+			teamC := config.VaultConfig.engineConfig("team-c")
+			Expect(teamC).NotTo(BeIdenticalTo(config.VaultConfig))
+			Expect(teamC.Namespace).To(Equal("admin/team-c"))
+			Expect(teamC.Address).To(Equal("https://vault.example.com:8200"))
+		})
+
+		It("Should resolve the credentials of every engine on its own", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+			factory, err := NewBackendFactory(fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(backendConfig, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "bmc-secret-operator-system"},
+					Data:       map[string][]byte{"token": []byte("hvs.base")},
+				}, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "vault-token-b", Namespace: "bmc-secret-operator-system"},
+					Data:       map[string][]byte{"token": []byte("hvs.team-b")},
+				}).
+				Build(), nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			config, err := factory.loadConfig(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			for engine, token := range map[string]string{"shared": "hvs.base", "team-b": "hvs.team-b"} {
+				resolved, err := withAuthSecret(context.Background(), factory.client, config.VaultConfig.engineConfig(engine))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resolved.Token).To(Equal(token))
+			}
+		})
+
+		It("Should report the secrets referenced by engines", func() {
+			refs := AuthSecretReferences(backendConfig)
+			Expect(refs).To(ConsistOf(
+				HaveField("Name", "vault-token"),
+				HaveField("Name", "vault-token-b"),
+			))
+		})
+
+		It("Should only fail the engine whose secret cannot be resolved", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+			// Every engine overriding the auth method does not need the base secret
+			backendConfig.Spec.VaultConfig.SecretEngines = backendConfig.Spec.VaultConfig.SecretEngines[1:]
+			factory, err := NewBackendFactory(fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(backendConfig).
+				Build(), nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			config, err := factory.loadConfig(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = newEngineBackend(context.Background(), factory.client, config, config.VaultConfig, &config.SecretEngines[1], nil)
+			Expect(err).To(MatchError(ErrCredentialsUnavailable))
+			Expect(err).To(MatchError(ContainSubstring("secret engine team-b: credentials unavailable: failed to resolve token auth secret")))
+			Expect(EngineErrors(err)).To(ConsistOf(HaveField("Engine", "team-b")))
+		})
+	})
+
+	Context("When validating a config", func() {
+		var backendConfig *configv1alpha1.SecretBackendConfig
+
+		BeforeEach(func() {
+			backendConfig = &configv1alpha1.SecretBackendConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: DefaultBackendConfigName},
+				Spec: configv1alpha1.SecretBackendConfigSpec{
+					Backend: "vault",
+					VaultConfig: &configv1alpha1.VaultConfig{
+						Address: "https://vault.example.com:8200",
+						SecretEngines: []configv1alpha1.SecretEngineConfig{
+							{Name: "team-a", MountPath: "team-a", SyncLabel: "team=a"},
+							{Name: "team-b", MountPath: "team-b", SyncLabel: "team=b"},
+						},
+					},
+				},
+			}
+		})
+
+		It("Should accept a valid config", func() {
+			Expect(ValidateConfig(backendConfig)).To(Succeed())
+		})
+
+		It("Should require the configuration of the selected backend", func() {
+			backendConfig.Spec.Backend = "openbao"
+
+			err := ValidateConfig(backendConfig)
+			Expect(err).To(MatchError(ContainSubstring("openBaoConfig is required when backend is openbao")))
+		})
+
+		It("Should reject path templates referencing unknown variables", func() {
+			backendConfig.Spec.PathTemplate = "bmc/{{.Datacenter}}/{{.Hostname}}"
+			backendConfig.Spec.VaultConfig.SecretEngines[0].PathTemplate = "bmc/{{.Hostname"
+
+			err := ValidateConfig(backendConfig)
+			Expect(err).To(MatchError(ContainSubstring("can't evaluate field Datacenter")))
+			Expect(err).To(MatchError(ContainSubstring("engine team-a: failed to parse path template")))
+		})
+
+		It("Should accept path templates using BMC variables and functions", func() {
+			backendConfig.Spec.PathTemplate = `bmc/{{.Labels.rack | default "no-rack"}}/{{.Hostname | trimSuffix ".example.com"}}/{{.Port}}`
+			backendConfig.Spec.VaultConfig.SecretEngines[0].PathTemplate = `{{.Protocol | lower}}/{{.MACAddress | replace ":" ""}}/{{.BMCName}}`
+
+			Expect(ValidateConfig(backendConfig)).To(Succeed())
+		})
+
+		It("Should reject path templates rendering invalid paths", func() {
+			backendConfig.Spec.PathTemplate = "bmc/{{.Region}}/BMC credentials/{{.Hostname}}"
+			backendConfig.Spec.VaultConfig.SecretEngines[0].PathTemplate = `{{.Hostname | trimSuffix .Hostname}}/`
+
+			err := ValidateConfig(backendConfig)
+			Expect(err).To(MatchError(ContainSubstring(`segment "BMC credentials" may only contain`)))
+			Expect(err).To(MatchError(ContainSubstring("engine team-a: invalid secret path: the path is empty")))
+		})
+
+		It("Should reject path templates rendering the same path for distinct BMCs", func() {
+			backendConfig.Spec.PathTemplate = "bmc/{{.Region}}/{{.Username}}"
+			backendConfig.Spec.VaultConfig.SecretEngines[0].PathTemplate = "team-a/{{.Username}}"
+
+			err := ValidateDistinctPaths(backendConfig)
+			Expect(err).To(MatchError(ContainSubstring("path template renders the same path bmc/us-east-1/admin for distinct BMCs")))
+			Expect(err).To(MatchError(ContainSubstring("engine team-a: path template renders the same path team-a/admin for distinct BMCs")))
+
+			// Stored configs rendering the same path keep syncing
+			Expect(ValidateConfig(backendConfig)).To(Succeed())
+		})
+
+		It("Should render the labels and annotations path templates reference with sample values", func() {
+			backendConfig.Spec.PathTemplate = `bmc/{{.Labels.rack}}/{{.Username}}`
+			backendConfig.Spec.VaultConfig.SecretEngines[0].PathTemplate = `{{if .Labels.zone}}{{index .Annotations "serial"}}{{end}}/{{.Username}}`
+
+			Expect(ValidateConfig(backendConfig)).To(Succeed())
+			Expect(ValidateDistinctPaths(backendConfig)).To(Succeed())
+		})
+
+		It("Should reject duplicate engine names", func() {
+			backendConfig.Spec.VaultConfig.SecretEngines[1].Name = "team-a"
+
+			Expect(ValidateConfig(backendConfig)).To(MatchError(ContainSubstring("duplicate engine name team-a")))
+		})
+
+		It("Should reject engines writing to the same mount with the same template", func() {
+			backendConfig.Spec.VaultConfig.SecretEngines[1].MountPath = "team-a"
+
+			Expect(ValidateConfig(backendConfig)).To(MatchError(ContainSubstring(
+				"engines team-a and team-b write to the same mount team-a with the same path template")))
+
+			// A different template or server keeps the secrets apart
+			backendConfig.Spec.VaultConfig.SecretEngines[1].PathTemplate = "team-b/{{.Hostname}}/{{.Username}}"
+			Expect(ValidateConfig(backendConfig)).To(Succeed())
+
+			backendConfig.Spec.VaultConfig.SecretEngines[1].PathTemplate = ""
+			backendConfig.Spec.VaultConfig.SecretEngines[1].Address = "https://vault-b.example.com:8200"
+			Expect(ValidateConfig(backendConfig)).To(Succeed())
+		})
+	})
+
+	Context("When defaulting a config", func() {
+		It("Should fill in the defaults assumed when loading it", func() {
+			backendConfig := &configv1alpha1.SecretBackendConfig{
+				Spec: configv1alpha1.SecretBackendConfigSpec{
+					Backend: "vault",
+					VaultConfig: &configv1alpha1.VaultConfig{
+						Address:        "https://vault.example.com:8200",
+						KubernetesAuth: &configv1alpha1.KubernetesAuthConfig{Role: "bmc-operator"},
+						SecretEngines: []configv1alpha1.SecretEngineConfig{{
+							Name:        "team-a",
+							MountPath:   "team-a",
+							AppRoleAuth: &configv1alpha1.AppRoleAuthConfig{RoleID: "team-a"},
+						}},
+					},
+				},
+			}
+
+			SetDefaults(backendConfig)
+			Expect(backendConfig.Spec.PathTemplate).To(Equal(DefaultPathTemplate))
+			Expect(backendConfig.Spec.RegionLabelKey).To(Equal("region"))
+			Expect(backendConfig.Spec.OrphanPolicy).To(Equal(OrphanPolicyDelete))
+			Expect(backendConfig.Spec.AdoptPolicy).To(Equal(AdoptPolicyRefuse))
+			Expect(backendConfig.Spec.DeletionPolicy).To(Equal(DeletionPolicyDestroy))
+			Expect(backendConfig.Spec.MaxConcurrentSyncs).To(Equal(int32(DefaultMaxConcurrentSyncs)))
+			Expect(backendConfig.Spec.VerificationInterval.Duration).To(Equal(DefaultVerificationInterval))
+			Expect(backendConfig.Spec.VaultConfig.AuthMethod).To(Equal("kubernetes"))
+			Expect(backendConfig.Spec.VaultConfig.MountPath).To(Equal("secret"))
+			Expect(backendConfig.Spec.VaultConfig.KubernetesAuth.Path).To(Equal("kubernetes"))
+
+			// Engines inherit unset fields from the config, only their auth paths are defaulted
+			engine := backendConfig.Spec.VaultConfig.SecretEngines[0]
+			Expect(engine.AppRoleAuth.Path).To(Equal("approle"))
+			Expect(engine.AuthMethod).To(BeEmpty())
+			Expect(engine.PathTemplate).To(BeEmpty())
+		})
+
+		It("Should not modify the config when loading it", func() {
+			backendConfig := &configv1alpha1.SecretBackendConfig{
+				Spec: configv1alpha1.SecretBackendConfigSpec{
+					Backend:     "vault",
+					VaultConfig: &configv1alpha1.VaultConfig{Address: "https://vault.example.com:8200"},
+				},
+			}
+
+			config, err := LoadConfigFromCRD(backendConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.PathTemplate).To(Equal(DefaultPathTemplate))
+			Expect(backendConfig.Spec.PathTemplate).To(BeEmpty())
+			Expect(backendConfig.Spec.VaultConfig.MountPath).To(BeEmpty())
+		})
+	})
+
+	Context("When multiple SecretBackendConfigs exist", func() {
+		newConfig := func(name, syncSelector string) *configv1alpha1.SecretBackendConfig {
+			return &configv1alpha1.SecretBackendConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: configv1alpha1.SecretBackendConfigSpec{
+					Backend:   "vault",
+					SyncLabel: syncSelector,
+					VaultConfig: &configv1alpha1.VaultConfig{
+						Address: "https://vault.example.com:8200",
+						KubernetesAuth: &configv1alpha1.KubernetesAuthConfig{
+							Role: "bmc-operator",
+						},
+					},
+				},
+			}
+		}
+
+		newRegistry := func(objs ...runtime.Object) *BackendRegistry {
+			scheme := runtime.NewScheme()
+			Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+			registry, err := NewBackendRegistry(fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(objs...).
+				Build(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			return registry
+		}
+
+		It("Should return a factory per config loading its own settings", func() {
+			registry := newRegistry(newConfig("vault-eu", "region=eu"), newConfig("vault-us", "region=us"))
+
+			factories, err := registry.GetFactories(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(factories).To(HaveLen(2))
+
+			for name, region := range map[string]string{"vault-eu": "eu", "vault-us": "us"} {
+				Expect(factories).To(HaveKey(name))
+				configName, err := factories[name].GetConfigName(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(configName).To(Equal(name))
+
+				syncSelector, err := factories[name].GetSyncSelector(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(syncSelector.Matches(labels.Set{"region": region})).To(BeTrue())
+				Expect(syncSelector.Matches(labels.Set{"region": "apac"})).To(BeFalse())
+			}
+		})
+
+		It("Should reset factories in place when their cache is invalidated", func() {
+			ctx := context.Background()
+			registry := newRegistry(newConfig("vault-eu", "region=eu"), newConfig("vault-us", ""))
+
+			before, err := registry.GetFactories(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			syncSelector, err := before["vault-eu"].GetSyncSelector(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(syncSelector.Matches(labels.Set{"region": "eu"})).To(BeTrue())
+
+			config := &configv1alpha1.SecretBackendConfig{}
+			Expect(registry.client.Get(ctx, types.NamespacedName{Name: "vault-eu"}, config)).To(Succeed())
+			config.Spec.SyncLabel = "region=apac"
+			Expect(registry.client.Update(ctx, config)).To(Succeed())
+
+			Expect(registry.InvalidateCache("vault-eu")).To(Succeed())
+
+			after, err := registry.GetFactories(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after["vault-us"]).To(BeIdenticalTo(before["vault-us"]))
+			// Reconciles still holding the factory see the new configuration as well
+			Expect(after["vault-eu"]).To(BeIdenticalTo(before["vault-eu"]))
+			syncSelector, err = before["vault-eu"].GetSyncSelector(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(syncSelector.Matches(labels.Set{"region": "apac"})).To(BeTrue())
+		})
+
+		It("Should cache sync selectors per config generation", func() {
+			ctx := context.Background()
+			registry := newRegistry(newConfig("vault-eu", "region=eu"), newConfig("vault-us", "region in ("))
+
+			selectors, err := registry.SyncSelectors(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selectors).To(HaveLen(2))
+			Expect(selectors["vault-eu"].Matches(labels.Set{"region": "eu"})).To(BeTrue())
+			Expect(selectors).To(HaveKeyWithValue("vault-us", BeNil()))
+
+			config := &configv1alpha1.SecretBackendConfig{}
+			Expect(registry.client.Get(ctx, types.NamespacedName{Name: "vault-eu"}, config)).To(Succeed())
+			config.Spec.SyncLabel = "region=apac"
+			Expect(registry.client.Update(ctx, config)).To(Succeed())
+
+			// The selector is only parsed again once the generation changes
+			selectors, err = registry.SyncSelectors(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selectors["vault-eu"].Matches(labels.Set{"region": "eu"})).To(BeTrue())
+
+			Expect(registry.client.Get(ctx, types.NamespacedName{Name: "vault-eu"}, config)).To(Succeed())
+			config.Generation++
+			Expect(registry.client.Update(ctx, config)).To(Succeed())
+
+			selectors, err = registry.SyncSelectors(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selectors["vault-eu"].Matches(labels.Set{"region": "apac"})).To(BeTrue())
+		})
+
+		It("Should drop the factory of a deleted config", func() {
+			ctx := context.Background()
+			registry := newRegistry(newConfig("vault-eu", "region=eu"), newConfig("vault-us", ""))
+
+			before, err := registry.GetFactories(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = before["vault-eu"].GetSyncSelector(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(registry.client.Delete(ctx, newConfig("vault-eu", ""))).To(Succeed())
+			Expect(registry.RemoveFactory("vault-eu")).To(Succeed())
+			Expect(registry.factories).NotTo(HaveKey("vault-eu"))
+			Expect(registry.factories).To(HaveKey("vault-us"))
+
+			// Reconciles still holding the dropped factory cannot load its configuration anymore
+			_, err = before["vault-eu"].GetSyncSelector(ctx)
+			Expect(err).To(HaveOccurred())
+
+			after, err := registry.GetFactories(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(HaveLen(1))
+			Expect(after["vault-us"]).To(BeIdenticalTo(before["vault-us"]))
+		})
+
+		It("Should fall back to environment variables without any config", func() {
+			DeferCleanup(os.Unsetenv, "VAULT_ADDR")
+			Expect(os.Setenv("VAULT_ADDR", "https://vault.example.com:8200")).To(Succeed())
+			registry := newRegistry()
+
+			factories, err := registry.GetFactories(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(factories).To(HaveLen(1))
+			Expect(factories).To(HaveKey(EnvConfigName))
+
+			configName, err := factories[EnvConfigName].GetConfigName(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configName).To(Equal(EnvConfigName))
+		})
+
+		It("Should not fall back to environment variables for a missing named config", func() {
+			scheme := runtime.NewScheme()
+			Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+			factory, err := NewConfigBackendFactory(fake.NewClientBuilder().WithScheme(scheme).Build(), nil, "vault-eu")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = factory.loadConfig(context.Background())
+			Expect(err).To(MatchError(ContainSubstring("failed to get SecretBackendConfig vault-eu")))
+		})
+	})
+})
@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	contentHashAlgorithm = "hmac-sha256"
+	contentHashSaltSize  = 16
+	contentHashKeySize   = 32
+)
+
+// processContentHashKey is the key of configurations without a content hash key. It
+// only lives as long as the process, so hashes recorded by a previous process no
+// longer match and the secrets are read back once.
+var processContentHashKey = sync.OnceValue(func() []byte {
+	key := make([]byte, contentHashKeySize)
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(key)
+	return key
+})
+
+// ContentHash returns a keyed hash of the secret data in the form
+// hmac-sha256:<salt>:<mac>. Without the key the hash cannot be used to guess the
+// data, and the random salt keeps equal credentials from producing equal hashes,
+// so the hash does not reveal reused passwords either.
+func ContentHash(key []byte, data map[string]any) (string, error) {
+	salt := make([]byte, contentHashSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	mac, err := saltedMAC(key, salt, data)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s:%s", contentHashAlgorithm, hex.EncodeToString(salt), hex.EncodeToString(mac)), nil
+}
+
+// ContentHashMatches reports whether hash was computed by ContentHash from data with key.
+// Hashes of other algorithms or keys never match.
+func ContentHashMatches(key []byte, hash string, data map[string]any) bool {
+	algorithm, rest, ok := strings.Cut(hash, ":")
+	if !ok || algorithm != contentHashAlgorithm {
+		return false
+	}
+	encodedSalt, encodedMAC, ok := strings.Cut(rest, ":")
+	if !ok {
+		return false
+	}
+
+	salt, err := hex.DecodeString(encodedSalt)
+	if err != nil {
+		return false
+	}
+	expected, err := hex.DecodeString(encodedMAC)
+	if err != nil {
+		return false
+	}
+
+	mac, err := saltedMAC(key, salt, data)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(mac, expected)
+}
+
+// saltedMAC computes the HMAC of the salt followed by the canonical JSON encoding of data
+func saltedMAC(key, salt []byte, data map[string]any) ([]byte, error) {
+	// encoding/json sorts map keys, so equal data always encodes the same way
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode secret data: %w", err)
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write(salt)
+	h.Write(encoded)
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContentHash", func() {
+	key := []byte("content-hash-key")
+	data := map[string]any{"username": "admin", "password": "secret123"}
+
+	It("Should match the data it was computed from", func() {
+		hash, err := ContentHash(key, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hash).To(HavePrefix("hmac-sha256:"))
+		Expect(hash).NotTo(ContainSubstring("secret123"))
+
+		Expect(ContentHashMatches(key, hash, map[string]any{"password": "secret123", "username": "admin"})).To(BeTrue())
+	})
+
+	It("Should not match changed data", func() {
+		hash, err := ContentHash(key, data)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ContentHashMatches(key, hash, map[string]any{"username": "admin", "password": "changed"})).To(BeFalse())
+		Expect(ContentHashMatches(key, hash, map[string]any{"username": "root", "password": "secret123"})).To(BeFalse())
+		Expect(ContentHashMatches(key, hash, map[string]any{"username": "admin", "password": "secret123", "extra": "x"})).To(BeFalse())
+	})
+
+	It("Should not match with a different key", func() {
+		hash, err := ContentHash(key, data)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ContentHashMatches([]byte("other-key"), hash, data)).To(BeFalse())
+	})
+
+	It("Should use a different salt for every hash", func() {
+		first, err := ContentHash(key, data)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := ContentHash(key, data)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(first).NotTo(Equal(second))
+		Expect(ContentHashMatches(key, second, data)).To(BeTrue())
+	})
+
+	It("Should not match malformed hashes", func() {
+		Expect(ContentHashMatches(key, "", data)).To(BeFalse())
+		Expect(ContentHashMatches(key, "md5:00:00", data)).To(BeFalse())
+		Expect(ContentHashMatches(key, "hmac-sha256:zz:00", data)).To(BeFalse())
+		Expect(ContentHashMatches(key, "hmac-sha256:00", data)).To(BeFalse())
+	})
+})
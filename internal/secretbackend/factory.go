@@ -18,12 +18,13 @@ package secretbackend
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
-	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/vault"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -32,27 +33,42 @@ const (
 	DefaultBackendConfigName = "default-backend-config"
 )
 
-// BackendFactory manages backend instances
+// BackendFactory manages the backend instances of a single configuration
 type BackendFactory struct {
 	client           client.Client
+	configName       string
 	backend          Backend
 	pathBuilder      *PathBuilder
 	config           *Config
 	metricsCollector MetricsCollector
 	engineBackends   []*EngineBackend
-	mu               sync.RWMutex
+	// engineErrs holds the EngineErrors of the engines whose backend could not be
+	// created, which is retried on the next lookup
+	engineErrs []error
+	mu         sync.RWMutex
 }
 
 // MetricsCollector defines the interface for recording metrics
 // This allows the factory to be independent of the metrics implementation
 type MetricsCollector interface {
-	RecordAuth(method, backendType string, duration time.Duration, err error)
+	RecordBackendOperation(operation, backendType, namespace string, duration time.Duration, err error)
+	RecordBackendOperationWithEngine(operation, backendType, engine, namespace string, duration time.Duration, err error)
+	RecordAuth(method, backendType, namespace string, duration time.Duration, err error)
+	RecordTokenTTL(backendType, configName, engine, namespace string, ttl time.Duration)
+	RecordTokenRenewal(backendType, configName, engine, namespace string, err error)
 }
 
-// NewBackendFactory creates a new backend factory
+// NewBackendFactory creates a new backend factory for the default SecretBackendConfig
 func NewBackendFactory(c client.Client, metricsCollector MetricsCollector) (*BackendFactory, error) {
+	return NewConfigBackendFactory(c, metricsCollector, DefaultBackendConfigName)
+}
+
+// NewConfigBackendFactory creates a new backend factory for the named SecretBackendConfig.
+// An empty name selects the configuration from environment variables.
+func NewConfigBackendFactory(c client.Client, metricsCollector MetricsCollector, configName string) (*BackendFactory, error) {
 	return &BackendFactory{
 		client:           c,
+		configName:       configName,
 		metricsCollector: metricsCollector,
 	}, nil
 }
@@ -74,21 +90,18 @@ func (f *BackendFactory) GetBackend(ctx context.Context) (Backend, error) {
 		return f.backend, nil
 	}
 
-	// Load configuration
-	config, err := f.loadConfig(ctx)
+	config, err := f.configLocked(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load configuration: %w", err)
+		return nil, err
 	}
 
 	// Create backend
-	backend, err := f.createBackend(config)
+	backend, err := f.createBackend(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create backend: %w", err)
 	}
 
 	f.backend = backend
-	f.config = config
-
 	return backend, nil
 }
 
@@ -109,17 +122,13 @@ func (f *BackendFactory) GetPathBuilder(ctx context.Context) (*PathBuilder, erro
 		return f.pathBuilder, nil
 	}
 
-	// Load configuration if not already loaded
-	if f.config == nil {
-		config, err := f.loadConfig(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load configuration: %w", err)
-		}
-		f.config = config
+	config, err := f.configLocked(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create path builder
-	pathBuilder, err := NewPathBuilder(f.config.PathTemplate)
+	pathBuilder, err := NewPathBuilder(config.PathTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create path builder: %w", err)
 	}
@@ -130,88 +139,175 @@ func (f *BackendFactory) GetPathBuilder(ctx context.Context) (*PathBuilder, erro
 
 // GetRegionLabelKey returns the configured region label key
 func (f *BackendFactory) GetRegionLabelKey(ctx context.Context) (string, error) {
-	f.mu.RLock()
-	if f.config != nil {
-		defer f.mu.RUnlock()
-		return f.config.RegionLabelKey, nil
-	}
-	f.mu.RUnlock()
+	return cached(ctx, f, func(c *Config) string { return c.RegionLabelKey })
+}
 
+// GetSyncSelector returns the selector of the BMCSecrets to sync (labels.Everything() if not configured)
+func (f *BackendFactory) GetSyncSelector(ctx context.Context) (labels.Selector, error) {
+	return cached(ctx, f, func(c *Config) labels.Selector { return c.SyncSelector })
+}
+
+// GetOrphanPolicy returns the configured orphan policy
+func (f *BackendFactory) GetOrphanPolicy(ctx context.Context) (string, error) {
+	return cached(ctx, f, func(c *Config) string { return c.OrphanPolicy })
+}
+
+// GetClusterID returns the ID of the cluster recorded in ownership markers
+func (f *BackendFactory) GetClusterID(ctx context.Context) (string, error) {
+	return cached(ctx, f, func(c *Config) string { return c.ClusterID })
+}
+
+// GetConfigName returns the name of the configuration recorded in ownership markers
+func (f *BackendFactory) GetConfigName(ctx context.Context) (string, error) {
+	return cached(ctx, f, func(c *Config) string { return c.Name })
+}
+
+// GetAdoptPolicy returns the configured adopt policy
+func (f *BackendFactory) GetAdoptPolicy(ctx context.Context) (string, error) {
+	return cached(ctx, f, func(c *Config) string { return c.AdoptPolicy })
+}
+
+// GetDeletionPolicy returns the configured deletion policy
+func (f *BackendFactory) GetDeletionPolicy(ctx context.Context) (string, error) {
+	return cached(ctx, f, func(c *Config) string { return c.DeletionPolicy })
+}
+
+// GetMaxConcurrentSyncs returns the number of paths synced in parallel per engine
+func (f *BackendFactory) GetMaxConcurrentSyncs(ctx context.Context) (int, error) {
+	return cached(ctx, f, func(c *Config) int { return c.MaxConcurrentSyncs })
+}
+
+// GetVerificationInterval returns how often synced secrets are read back and compared in full
+func (f *BackendFactory) GetVerificationInterval(ctx context.Context) (time.Duration, error) {
+	return cached(ctx, f, func(c *Config) time.Duration { return c.VerificationInterval })
+}
+
+// GetContentHashKey returns the key of the recorded content hashes
+func (f *BackendFactory) GetContentHashKey(ctx context.Context) ([]byte, error) {
+	return cached(ctx, f, (*Config).contentHashKey)
+}
+
+// GetLocation returns where the backend stores secrets
+func (f *BackendFactory) GetLocation(ctx context.Context) (Location, error) {
+	return cached(ctx, f, (*Config).location)
+}
+
+// NewLocationBackend creates a backend at a location the configuration no longer stores
+// the secrets of the engine at, empty for the base backend. It authenticates like the
+// engine does now, falling back to the base backend for engines no longer configured.
+// The backend is not cached, the caller closes it.
+func (f *BackendFactory) NewLocationBackend(ctx context.Context, engineName string, location Location) (Backend, error) {
 	f.mu.Lock()
-	defer f.mu.Unlock()
+	config, err := f.configLocked(ctx)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
 
-	if f.config == nil {
-		config, err := f.loadConfig(ctx)
-		if err != nil {
-			return "", fmt.Errorf("failed to load configuration: %w", err)
-		}
-		f.config = config
+	kvConfig := config.kvConfig()
+	if kvConfig == nil {
+		return nil, fmt.Errorf("%s configuration is required when backend is %s", config.Backend, config.Backend)
+	}
+	locationConfig, err := withAuthSecret(ctx, f.client, kvConfig.engineConfig(engineName))
+	if err != nil {
+		return nil, err
 	}
+	locationConfig.Address = location.Address
+	locationConfig.Namespace = location.Namespace
 
-	return f.config.RegionLabelKey, nil
+	backend, err := newKVBackend(config.Backend, locationConfig, KVBackendOptions{
+		MountPath:  location.MountPath,
+		ConfigName: config.Name,
+		EngineName: engineName,
+	}, f.metricsCollector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backend at %s: %w", location.Address, err)
+	}
+	if f.metricsCollector != nil {
+		backend = newInstrumentedBackend(backend, config.Backend, engineName, location.Namespace, f.metricsCollector)
+	}
+	return backend, nil
 }
 
-// GetSyncLabel returns the configured sync label key (empty string if not configured)
-func (f *BackendFactory) GetSyncLabel(ctx context.Context) (string, error) {
+// cached returns a value of the configuration, loading the configuration on first use
+func cached[T any](ctx context.Context, f *BackendFactory, get func(*Config) T) (T, error) {
 	f.mu.RLock()
 	if f.config != nil {
 		defer f.mu.RUnlock()
-		return f.config.SyncLabel, nil
+		return get(f.config), nil
 	}
 	f.mu.RUnlock()
 
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
+	config, err := f.configLocked(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return get(config), nil
+}
+
+// configLocked returns the cached configuration, loading it if not loaded yet.
+// Must be called with mu held for writing.
+func (f *BackendFactory) configLocked(ctx context.Context) (*Config, error) {
 	if f.config == nil {
 		config, err := f.loadConfig(ctx)
 		if err != nil {
-			return "", fmt.Errorf("failed to load configuration: %w", err)
+			return nil, fmt.Errorf("failed to load configuration: %w", err)
 		}
 		f.config = config
 	}
-
-	return f.config.SyncLabel, nil
+	return f.config, nil
 }
 
 // loadConfig loads configuration from CRD or environment variables
 func (f *BackendFactory) loadConfig(ctx context.Context) (*Config, error) {
+	if f.configName == "" {
+		return LoadConfigFromEnv()
+	}
+
 	// Try to load from CRD first
 	var backendConfig configv1alpha1.SecretBackendConfig
-	err := f.client.Get(ctx, types.NamespacedName{Name: DefaultBackendConfigName}, &backendConfig)
+	err := f.client.Get(ctx, types.NamespacedName{Name: f.configName}, &backendConfig)
 	if err == nil {
-		return LoadConfigFromCRD(&backendConfig)
+		config, err := LoadConfigFromCRD(&backendConfig)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.resolveSecretRefs(ctx, config); err != nil {
+			return nil, err
+		}
+		return config, nil
 	}
 
-	// Fall back to environment variables
+	// Only the default configuration falls back to environment variables
+	if f.configName != DefaultBackendConfigName {
+		return nil, fmt.Errorf("failed to get SecretBackendConfig %s: %w", f.configName, err)
+	}
 	return LoadConfigFromEnv()
 }
 
 // createBackend creates a backend instance based on configuration
-func (f *BackendFactory) createBackend(config *Config) (Backend, error) {
+func (f *BackendFactory) createBackend(ctx context.Context, config *Config) (Backend, error) {
 	var backend Backend
 	var err error
 
 	switch config.Backend {
-	case defaultBackendType:
-		if config.VaultConfig == nil {
-			return nil, fmt.Errorf("vault configuration is required when backend is vault")
+	case defaultBackendType, openBaoBackendType:
+		kvConfig := config.kvConfig()
+		if kvConfig == nil {
+			return nil, fmt.Errorf("%s configuration is required when backend is %s", config.Backend, config.Backend)
 		}
-		// Convert internal config to vault.Config
-		vaultConfig := &vault.Config{
-			Address:            config.VaultConfig.Address,
-			AuthMethod:         config.VaultConfig.AuthMethod,
-			KubernetesAuthRole: config.VaultConfig.KubernetesAuthRole,
-			KubernetesAuthPath: config.VaultConfig.KubernetesAuthPath,
-			Token:              config.VaultConfig.Token,
-			MountPath:          config.VaultConfig.MountPath,
-			SkipVerify:         config.VaultConfig.SkipVerify,
-			CACert:             config.VaultConfig.CACert,
+		resolved, resolveErr := withAuthSecret(ctx, f.client, kvConfig)
+		if resolveErr != nil {
+			return nil, resolveErr
 		}
-		backend, err = vault.NewVaultBackend(vaultConfig, f.metricsCollector)
-
-	case "openbao":
-		return nil, fmt.Errorf("OpenBao backend not yet implemented")
+		backend, err = newKVBackend(config.Backend, resolved, KVBackendOptions{
+			MountPath:  kvConfig.MountPath,
+			ConfigName: config.Name,
+		}, f.metricsCollector)
 
 	default:
 		return nil, fmt.Errorf("unsupported backend type: %s", config.Backend)
@@ -223,162 +319,63 @@ func (f *BackendFactory) createBackend(config *Config) (Backend, error) {
 
 	// Wrap backend with metrics instrumentation if metrics collector is available
 	if f.metricsCollector != nil {
-		backend = newInstrumentedBackend(backend, config.Backend, f.metricsCollector)
+		backend = newInstrumentedBackend(backend, config.Backend, "", config.namespace(), f.metricsCollector)
 	}
 
 	return backend, nil
 }
 
-// newInstrumentedBackend wraps a backend with metrics instrumentation
-func newInstrumentedBackend(backend Backend, backendType string, collector MetricsCollector) Backend {
+// newInstrumentedBackend wraps a backend with metrics instrumentation. The engine name
+// is empty for the base backend.
+func newInstrumentedBackend(backend Backend, backendType, engineName, namespace string, collector MetricsCollector) Backend {
 	return &instrumentedBackend{
-		backend:     backend,
-		backendType: backendType,
-		collector:   collector,
-	}
-}
-
-// newInstrumentedBackendWithEngine wraps a backend with metrics instrumentation including engine name
-func newInstrumentedBackendWithEngine(backend Backend, backendType, engineName string, collector MetricsCollector) Backend {
-	return &instrumentedBackendWithEngine{
 		backend:     backend,
 		backendType: backendType,
 		engineName:  engineName,
+		namespace:   namespace,
 		collector:   collector,
 	}
 }
 
-// instrumentedBackendWithEngine wraps a Backend with metrics instrumentation including engine name
-type instrumentedBackendWithEngine struct {
+// instrumentedBackend wraps a Backend with metrics instrumentation
+type instrumentedBackend struct {
 	backend     Backend
 	backendType string
 	engineName  string
+	namespace   string
 	collector   MetricsCollector
 }
 
-// WriteSecret writes a secret and records metrics
-func (i *instrumentedBackendWithEngine) WriteSecret(ctx context.Context, path string, data map[string]any) error {
-	start := time.Now()
-	err := i.backend.WriteSecret(ctx, path, data)
-	duration := time.Since(start)
-
-	if mc, ok := i.collector.(interface {
-		RecordBackendOperationWithEngine(operation, backendType, engine string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperationWithEngine("write", i.backendType, i.engineName, duration, err)
-	} else if mc, ok := i.collector.(interface {
-		RecordBackendOperation(operation, backendType string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperation("write", i.backendType, duration, err)
-	}
-	return err
-}
-
-// ReadSecret reads a secret and records metrics
-func (i *instrumentedBackendWithEngine) ReadSecret(ctx context.Context, path string) (map[string]any, error) {
-	start := time.Now()
-	data, err := i.backend.ReadSecret(ctx, path)
-	duration := time.Since(start)
-
-	if mc, ok := i.collector.(interface {
-		RecordBackendOperationWithEngine(operation, backendType, engine string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperationWithEngine("read", i.backendType, i.engineName, duration, err)
-	} else if mc, ok := i.collector.(interface {
-		RecordBackendOperation(operation, backendType string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperation("read", i.backendType, duration, err)
+// record records the duration and result of an operation started at start
+func (i *instrumentedBackend) record(operation string, start time.Time, err error) {
+	if i.engineName == "" {
+		i.collector.RecordBackendOperation(operation, i.backendType, i.namespace, time.Since(start), err)
+		return
 	}
-	return data, err
-}
-
-// DeleteSecret deletes a secret and records metrics
-func (i *instrumentedBackendWithEngine) DeleteSecret(ctx context.Context, path string) error {
-	start := time.Now()
-	err := i.backend.DeleteSecret(ctx, path)
-	duration := time.Since(start)
-
-	if mc, ok := i.collector.(interface {
-		RecordBackendOperationWithEngine(operation, backendType, engine string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperationWithEngine("delete", i.backendType, i.engineName, duration, err)
-	} else if mc, ok := i.collector.(interface {
-		RecordBackendOperation(operation, backendType string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperation("delete", i.backendType, duration, err)
-	}
-	return err
-}
-
-// SecretExists checks if a secret exists and records metrics
-func (i *instrumentedBackendWithEngine) SecretExists(ctx context.Context, path string) (bool, error) {
-	start := time.Now()
-	exists, err := i.backend.SecretExists(ctx, path)
-	duration := time.Since(start)
-
-	if mc, ok := i.collector.(interface {
-		RecordBackendOperationWithEngine(operation, backendType, engine string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperationWithEngine("exists", i.backendType, i.engineName, duration, err)
-	} else if mc, ok := i.collector.(interface {
-		RecordBackendOperation(operation, backendType string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperation("exists", i.backendType, duration, err)
-	}
-	return exists, err
-}
-
-// Close closes the backend
-func (i *instrumentedBackendWithEngine) Close() error {
-	return i.backend.Close()
-}
-
-// instrumentedBackend wraps a Backend with metrics instrumentation
-type instrumentedBackend struct {
-	backend     Backend
-	backendType string
-	collector   MetricsCollector
+	i.collector.RecordBackendOperationWithEngine(operation, i.backendType, i.engineName, i.namespace, time.Since(start), err)
 }
 
 // WriteSecret writes a secret and records metrics
-func (i *instrumentedBackend) WriteSecret(ctx context.Context, path string, data map[string]any) error {
+func (i *instrumentedBackend) WriteSecret(ctx context.Context, path string, data map[string]any, opts WriteOptions) (SecretVersion, error) {
 	start := time.Now()
-	err := i.backend.WriteSecret(ctx, path, data)
-	duration := time.Since(start)
-
-	if mc, ok := i.collector.(interface {
-		RecordBackendOperation(operation, backendType string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperation("write", i.backendType, duration, err)
-	}
-	return err
+	written, err := i.backend.WriteSecret(ctx, path, data, opts)
+	i.record("write", start, err)
+	return written, err
 }
 
 // ReadSecret reads a secret and records metrics
-func (i *instrumentedBackend) ReadSecret(ctx context.Context, path string) (map[string]any, error) {
+func (i *instrumentedBackend) ReadSecret(ctx context.Context, path string) (*Secret, error) {
 	start := time.Now()
-	data, err := i.backend.ReadSecret(ctx, path)
-	duration := time.Since(start)
-
-	if mc, ok := i.collector.(interface {
-		RecordBackendOperation(operation, backendType string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperation("read", i.backendType, duration, err)
-	}
-	return data, err
+	secret, err := i.backend.ReadSecret(ctx, path)
+	i.record("read", start, err)
+	return secret, err
 }
 
 // DeleteSecret deletes a secret and records metrics
-func (i *instrumentedBackend) DeleteSecret(ctx context.Context, path string) error {
+func (i *instrumentedBackend) DeleteSecret(ctx context.Context, path string, opts DeleteOptions) error {
 	start := time.Now()
-	err := i.backend.DeleteSecret(ctx, path)
-	duration := time.Since(start)
-
-	if mc, ok := i.collector.(interface {
-		RecordBackendOperation(operation, backendType string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperation("delete", i.backendType, duration, err)
-	}
+	err := i.backend.DeleteSecret(ctx, path, opts)
+	i.record("delete", start, err)
 	return err
 }
 
@@ -386,16 +383,15 @@ func (i *instrumentedBackend) DeleteSecret(ctx context.Context, path string) err
 func (i *instrumentedBackend) SecretExists(ctx context.Context, path string) (bool, error) {
 	start := time.Now()
 	exists, err := i.backend.SecretExists(ctx, path)
-	duration := time.Since(start)
-
-	if mc, ok := i.collector.(interface {
-		RecordBackendOperation(operation, backendType string, duration time.Duration, err error)
-	}); ok {
-		mc.RecordBackendOperation("exists", i.backendType, duration, err)
-	}
+	i.record("exists", start, err)
 	return exists, err
 }
 
+// CheckHealth checks the health of the backend
+func (i *instrumentedBackend) CheckHealth(ctx context.Context) error {
+	return i.backend.CheckHealth(ctx)
+}
+
 // Close closes the backend
 func (i *instrumentedBackend) Close() error {
 	return i.backend.Close()
@@ -406,14 +402,7 @@ func (f *BackendFactory) Close() error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	if f.backend != nil {
-		if err := f.backend.Close(); err != nil {
-			return err
-		}
-		f.backend = nil
-	}
-
-	return nil
+	return f.closeBackendsLocked()
 }
 
 // InvalidateCache invalidates the cached configuration and backend
@@ -422,38 +411,69 @@ func (f *BackendFactory) InvalidateCache() error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	// Close existing backend
+	err := f.closeBackendsLocked()
+
+	// Clear cached config and path builder
+	f.config = nil
+	f.pathBuilder = nil
+
+	return err
+}
+
+// closeBackendsLocked closes the base backend and the engine backends, so they are
+// created again on next use. Must be called with mu held for writing.
+func (f *BackendFactory) closeBackendsLocked() error {
+	var errs []error
 	if f.backend != nil {
 		if err := f.backend.Close(); err != nil {
-			return fmt.Errorf("failed to close backend during cache invalidation: %w", err)
+			errs = append(errs, fmt.Errorf("failed to close backend: %w", err))
 		}
 		f.backend = nil
 	}
 
-	// Close engine backends
 	for _, eb := range f.engineBackends {
 		if eb.Backend != nil {
 			if err := eb.Backend.Close(); err != nil {
-				return fmt.Errorf("failed to close engine backend %s: %w", eb.EngineName, err)
+				errs = append(errs, fmt.Errorf("failed to close engine backend %s: %w", eb.EngineName, err))
 			}
 		}
 	}
 	f.engineBackends = nil
+	f.engineErrs = nil
 
-	// Clear cached config and path builder
-	f.config = nil
-	f.pathBuilder = nil
-
-	return nil
+	return errors.Join(errs...)
 }
 
 // GetEngineBackends returns engine backends that match the given labels
 // If no secret engines are configured, returns empty slice (backward compatibility)
+// Engines matching the labels whose backend cannot be created are reported as EngineErrors
+// joined in the error, together with the engine backends that could be created.
 func (f *BackendFactory) GetEngineBackends(ctx context.Context, labels map[string]string) ([]*EngineBackend, error) {
+	engineBackends, err := f.GetAllEngineBackends(ctx)
+	engineErrs := EngineErrors(err)
+	if err != nil && len(engineErrs) == 0 {
+		return nil, err
+	}
+
+	var matchedErrs []error
+	for _, engineErr := range engineErrs {
+		if engineErr.MatchesLabels(labels) {
+			matchedErrs = append(matchedErrs, engineErr)
+		}
+	}
+	return f.filterEnginesByLabels(engineBackends, labels), errors.Join(matchedErrs...)
+}
+
+// GetAllEngineBackends returns all configured engine backends regardless of labels
+// If no secret engines are configured, returns empty slice (backward compatibility)
+// Engines whose backend cannot be created are reported as EngineErrors joined in the
+// error, together with the engine backends that could be created. Creating their
+// backends is retried on the next call.
+func (f *BackendFactory) GetAllEngineBackends(ctx context.Context) ([]*EngineBackend, error) {
 	f.mu.RLock()
-	if f.engineBackends != nil {
+	if f.engineBackends != nil && len(f.engineErrs) == 0 {
 		defer f.mu.RUnlock()
-		return f.filterEnginesByLabels(f.engineBackends, labels), nil
+		return f.engineBackends, nil
 	}
 	f.mu.RUnlock()
 
@@ -461,57 +481,50 @@ func (f *BackendFactory) GetEngineBackends(ctx context.Context, labels map[strin
 	defer f.mu.Unlock()
 
 	// Double-check after acquiring write lock
-	if f.engineBackends != nil {
-		return f.filterEnginesByLabels(f.engineBackends, labels), nil
-	}
-
-	// Load configuration if not already loaded
-	if f.config == nil {
-		config, err := f.loadConfig(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load configuration: %w", err)
-		}
-		f.config = config
+	if f.engineBackends != nil && len(f.engineErrs) == 0 {
+		return f.engineBackends, nil
 	}
 
-	// Check if multi-engine configuration exists
-	if f.config.VaultConfig == nil {
-		return nil, nil
-	}
-
-	// Get secret engines from CRD
-	var engines []configv1alpha1.SecretEngineConfig
-	var backendConfig configv1alpha1.SecretBackendConfig
-	err := f.client.Get(ctx, types.NamespacedName{Name: DefaultBackendConfigName}, &backendConfig)
+	config, err := f.configLocked(ctx)
 	if err != nil {
-		// No CRD config, return empty list
-		return nil, nil
-	}
-
-	if backendConfig.Spec.VaultConfig != nil {
-		engines = backendConfig.Spec.VaultConfig.SecretEngines
+		return nil, err
 	}
 
-	if len(engines) == 0 {
-		// No secret engines configured
+	// Check if multi-engine configuration exists
+	kvConfig := config.kvConfig()
+	if kvConfig == nil || len(config.SecretEngines) == 0 {
 		return nil, nil
 	}
 
-	// Create engine backends
-	engineBackends, err := parseSecretEngineConfig(engines, f.config.VaultConfig, f.metricsCollector)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse secret engine config: %w", err)
-	}
+	// Create the backends of the engines not created yet, keeping the ones created before
+	created := make(map[string]*EngineBackend, len(f.engineBackends))
+	for _, eb := range f.engineBackends {
+		created[eb.EngineName] = eb
+	}
+	engineBackends := make([]*EngineBackend, 0, len(config.SecretEngines))
+	var engineErrs []error
+	for i := range config.SecretEngines {
+		engine := &config.SecretEngines[i]
+		if eb, ok := created[engine.Name]; ok {
+			engineBackends = append(engineBackends, eb)
+			continue
+		}
 
-	// Wrap each backend with metrics instrumentation
-	for _, eb := range engineBackends {
+		eb, err := newEngineBackend(ctx, f.client, config, kvConfig, engine, f.metricsCollector)
+		if err != nil {
+			engineErrs = append(engineErrs, err)
+			continue
+		}
+		// Wrap the backend with metrics instrumentation
 		if f.metricsCollector != nil {
-			eb.Backend = newInstrumentedBackendWithEngine(eb.Backend, f.config.Backend, eb.EngineName, f.metricsCollector)
+			eb.Backend = newInstrumentedBackend(eb.Backend, config.Backend, eb.EngineName, eb.Namespace, f.metricsCollector)
 		}
+		engineBackends = append(engineBackends, eb)
 	}
 
 	f.engineBackends = engineBackends
-	return f.filterEnginesByLabels(engineBackends, labels), nil
+	f.engineErrs = engineErrs
+	return engineBackends, errors.Join(engineErrs...)
 }
 
 // filterEnginesByLabels filters engine backends by label matching
@@ -527,15 +540,5 @@ func (f *BackendFactory) filterEnginesByLabels(engines []*EngineBackend, labels
 
 // HasMultiEngineConfig checks if multi-engine configuration is present
 func (f *BackendFactory) HasMultiEngineConfig(ctx context.Context) (bool, error) {
-	var backendConfig configv1alpha1.SecretBackendConfig
-	err := f.client.Get(ctx, types.NamespacedName{Name: DefaultBackendConfigName}, &backendConfig)
-	if err != nil {
-		return false, nil
-	}
-
-	if backendConfig.Spec.VaultConfig != nil {
-		return len(backendConfig.Spec.VaultConfig.SecretEngines) > 0, nil
-	}
-
-	return false, nil
+	return cached(ctx, f, func(c *Config) bool { return len(c.SecretEngines) > 0 })
 }
@@ -18,26 +18,150 @@ package secretbackend
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
+// ErrSecretNotFound is returned by Backend.ReadSecret when no secret exists at the path.
+// Implementations must wrap it so callers can check with errors.Is.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// ErrCASMismatch is returned by Backend.WriteSecret when the secret changed since
+// the version passed for check-and-set. Implementations must wrap it.
+var ErrCASMismatch = errors.New("check-and-set mismatch")
+
+// ErrAuthentication is returned when creating a backend fails because logging in
+// to it failed. Implementations must wrap it.
+var ErrAuthentication = errors.New("failed to authenticate")
+
+// ErrMountDetection is returned when creating a backend fails because its KV mount
+// cannot be found or read. Implementations must wrap it.
+var ErrMountDetection = errors.New("failed to detect KV version")
+
+// Secret is a secret read from the backend together with its custom metadata
+type Secret struct {
+	// Data is the secret data
+	Data map[string]any
+
+	// Metadata is the custom metadata of the secret. KV v2 stores it as
+	// custom_metadata, KV v1 under a reserved data key
+	Metadata map[string]string
+
+	// SecretVersion is the KV v2 version of the data
+	SecretVersion
+}
+
+// SecretVersion describes a KV v2 version of a secret, as created by
+// Backend.WriteSecret. Backends without versioning, like KV v1 mounts, leave it zero.
+type SecretVersion struct {
+	// Version is the version number, starting at 1
+	Version int
+
+	// CreatedTime is when the version was written
+	CreatedTime time.Time
+}
+
+// WriteOptions controls how Backend.WriteSecret writes a secret
+type WriteOptions struct {
+	// Metadata holds custom metadata keys to set on the secret
+	Metadata map[string]string
+
+	// CAS makes the write fail with ErrCASMismatch unless the current version of
+	// the secret equals it, 0 meaning the secret must not exist yet. Without it the
+	// secret is written unconditionally. KV v1 mounts have no versions and ignore it.
+	CAS *int
+}
+
+// DeleteOptions controls how Backend.DeleteSecret deletes a secret
+type DeleteOptions struct {
+	// SoftDelete deletes only the latest KV v2 version, keeping older versions and
+	// the metadata so the secret can be recovered. Without it all versions and the
+	// metadata are destroyed. KV v1 mounts keep no versions and always remove the secret.
+	SoftDelete bool
+}
+
 // Backend defines the interface for secret backend operations
 type Backend interface {
-	// WriteSecret writes a secret to the backend at the specified path
-	WriteSecret(ctx context.Context, path string, data map[string]any) error
+	// WriteSecret writes a secret to the backend at the specified path, sets the
+	// custom metadata keys and applies check-and-set as given by the options.
+	// It returns the version the write created.
+	WriteSecret(ctx context.Context, path string, data map[string]any, opts WriteOptions) (SecretVersion, error)
 
-	// ReadSecret reads a secret from the backend at the specified path
-	ReadSecret(ctx context.Context, path string) (map[string]any, error)
+	// ReadSecret reads a secret and its custom metadata from the backend at the
+	// specified path, returning ErrSecretNotFound if there is none
+	ReadSecret(ctx context.Context, path string) (*Secret, error)
 
-	// DeleteSecret deletes a secret from the backend at the specified path
-	DeleteSecret(ctx context.Context, path string) error
+	// DeleteSecret deletes a secret from the backend at the specified path,
+	// destroying it or soft deleting it as given by the options
+	DeleteSecret(ctx context.Context, path string, opts DeleteOptions) error
 
 	// SecretExists checks if a secret exists at the specified path
 	SecretExists(ctx context.Context, path string) (bool, error)
 
+	// CheckHealth verifies that the backend is reachable and still authenticated
+	CheckHealth(ctx context.Context) error
+
 	// Close cleans up backend resources
 	Close() error
 }
 
+// KVBackendOptions selects the KV mount of a backend and identifies the backend
+// in the metrics it records
+type KVBackendOptions struct {
+	// MountPath is the path of the KV mount
+	MountPath string
+
+	// ConfigName is the name of the SecretBackendConfig the backend belongs to
+	ConfigName string
+
+	// EngineName is the name of the secret engine, empty for the base backend
+	EngineName string
+}
+
+// Location identifies where a backend stores secrets. Secrets synced to one location
+// are not found at another, so changing the location of a backend syncs them anew.
+type Location struct {
+	// Address is the URL of the server
+	Address string
+	// Namespace is the namespace on the server, empty for the root namespace
+	Namespace string
+	// MountPath is the path of the KV mount
+	MountPath string
+}
+
+// KVBackendConstructor creates a backend for a KV mount of a Vault-compatible server
+type KVBackendConstructor func(config *VaultConfigInternal, opts KVBackendOptions, metricsCollector MetricsCollector) (Backend, error)
+
+var (
+	kvBackendsMu sync.RWMutex
+	kvBackends   = make(map[string]KVBackendConstructor)
+)
+
+// RegisterKVBackend makes a backend type available to SecretBackendConfigs. Backend
+// implementations register themselves from an init function, so they are available
+// once their package is imported.
+func RegisterKVBackend(backendType string, constructor KVBackendConstructor) {
+	kvBackendsMu.Lock()
+	defer kvBackendsMu.Unlock()
+	kvBackends[backendType] = constructor
+}
+
+// newKVBackend creates a backend of the given registered type for the given mount
+func newKVBackend(backendType string, config *VaultConfigInternal, opts KVBackendOptions, metricsCollector MetricsCollector) (Backend, error) {
+	kvBackendsMu.RLock()
+	constructor, ok := kvBackends[backendType]
+	kvBackendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported backend type: %s", backendType)
+	}
+	return constructor(config, opts, metricsCollector)
+}
+
 // BackendFactoryInterface defines the interface for backend factory operations
 type BackendFactoryInterface interface {
 	// GetBackend returns the backend instance
@@ -49,12 +173,49 @@ type BackendFactoryInterface interface {
 	// GetRegionLabelKey returns the configured region label key
 	GetRegionLabelKey(ctx context.Context) (string, error)
 
-	// GetSyncLabel returns the configured sync label key
-	GetSyncLabel(ctx context.Context) (string, error)
+	// GetSyncSelector returns the selector of the BMCSecrets to sync
+	GetSyncSelector(ctx context.Context) (labels.Selector, error)
+
+	// GetOrphanPolicy returns the configured orphan policy (Delete, Retain or Report)
+	GetOrphanPolicy(ctx context.Context) (string, error)
+
+	// GetClusterID returns the ID of the cluster recorded in ownership markers
+	GetClusterID(ctx context.Context) (string, error)
+
+	// GetConfigName returns the name of the configuration recorded in ownership markers
+	GetConfigName(ctx context.Context) (string, error)
+
+	// GetAdoptPolicy returns the configured adopt policy (Refuse or Adopt)
+	GetAdoptPolicy(ctx context.Context) (string, error)
+
+	// GetDeletionPolicy returns the configured deletion policy (Retain, SoftDelete or Destroy)
+	GetDeletionPolicy(ctx context.Context) (string, error)
 
-	// GetEngineBackends returns engine backends that match the given labels
+	// GetMaxConcurrentSyncs returns the number of paths synced in parallel per engine
+	GetMaxConcurrentSyncs(ctx context.Context) (int, error)
+
+	// GetVerificationInterval returns how often synced secrets are read back and compared in full
+	GetVerificationInterval(ctx context.Context) (time.Duration, error)
+
+	// GetContentHashKey returns the key of the recorded content hashes
+	GetContentHashKey(ctx context.Context) ([]byte, error)
+
+	// GetLocation returns where the backend stores secrets
+	GetLocation(ctx context.Context) (Location, error)
+
+	// NewLocationBackend creates a backend at a location the configuration no longer
+	// stores the secrets of the engine at, empty for the base backend, with the
+	// credentials currently configured. The caller closes it.
+	NewLocationBackend(ctx context.Context, engineName string, location Location) (Backend, error)
+
+	// GetEngineBackends returns engine backends that match the given labels. Matching
+	// engines whose backend cannot be created are reported as EngineErrors in the error.
 	GetEngineBackends(ctx context.Context, labels map[string]string) ([]*EngineBackend, error)
 
+	// GetAllEngineBackends returns all configured engine backends regardless of labels.
+	// Engines whose backend cannot be created are reported as EngineErrors in the error.
+	GetAllEngineBackends(ctx context.Context) ([]*EngineBackend, error)
+
 	// HasMultiEngineConfig checks if multi-engine configuration is present
 	HasMultiEngineConfig(ctx context.Context) (bool, error)
 
@@ -64,3 +225,22 @@ type BackendFactoryInterface interface {
 	// Close closes the backend and cleans up resources
 	Close() error
 }
+
+// BackendRegistryInterface defines the interface for looking up the backend
+// factories of all configurations
+type BackendRegistryInterface interface {
+	// GetFactories returns the backend factory of every configuration by config name
+	GetFactories(ctx context.Context) (map[string]BackendFactoryInterface, error)
+
+	// SyncSelectors returns the sync selector of every configuration by config name,
+	// nil for a configuration whose selector is invalid
+	SyncSelectors(ctx context.Context) (map[string]labels.Selector, error)
+}
+
+// BackendProberInterface defines the interface for checking that the backends of
+// a configuration can be used
+type BackendProberInterface interface {
+	// Probe validates the configuration and connects to its base backend and every
+	// secret engine, returning the outcome per backend
+	Probe(ctx context.Context, config *configv1alpha1.SecretBackendConfig) ([]ProbeResult, error)
+}
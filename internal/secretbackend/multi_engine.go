@@ -17,100 +17,155 @@ limitations under the License.
 package secretbackend
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strings"
 
 	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
-	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/vault"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // EngineBackend represents a backend configured for a specific secret engine
 type EngineBackend struct {
-	Backend      Backend
-	EngineName   string
-	PathBuilder  *PathBuilder
-	SyncLabel    string
-	SyncLabelKey string
-	SyncLabelVal string
+	Backend     Backend
+	EngineName  string
+	PathBuilder *PathBuilder
+
+	// Location is where the engine stores secrets. Its namespace is also the one
+	// the engine authenticates in.
+	Location
+
+	// SyncSelector selects the BMCSecrets synced to this engine
+	SyncSelector labels.Selector
+
+	// MaxConcurrentSyncs is the number of paths synced in parallel to this engine
+	MaxConcurrentSyncs int
+
+	// DeletionPolicy controls how secrets are removed from this engine
+	DeletionPolicy string
 }
 
-// MatchesLabels checks if the given labels match this engine's sync label requirements
-func (e *EngineBackend) MatchesLabels(labels map[string]string) bool {
-	if labels == nil {
-		return false
-	}
+// MatchesLabels checks if the given labels match this engine's sync selector
+func (e *EngineBackend) MatchesLabels(bmcSecretLabels map[string]string) bool {
+	return e.SyncSelector.Matches(labels.Set(bmcSecretLabels))
+}
 
-	// If syncLabelVal is empty, just check if the key exists with any value
-	if e.SyncLabelVal == "" {
-		_, exists := labels[e.SyncLabelKey]
-		return exists
-	}
+// EngineError reports a secret engine whose backend could not be created. The other
+// engines of the configuration are still synced.
+type EngineError struct {
+	// Engine is the name of the secret engine
+	Engine string
+
+	// SyncSelector selects the BMCSecrets synced to the engine, nil if it is invalid
+	SyncSelector labels.Selector
 
-	// Otherwise, check for exact match
-	val, exists := labels[e.SyncLabelKey]
-	return exists && val == e.SyncLabelVal
+	Err error
 }
 
-// parseSecretEngineConfig parses SecretEngineConfig and creates EngineBackend instances
-func parseSecretEngineConfig(
-	engines []configv1alpha1.SecretEngineConfig,
-	baseVaultConfig *VaultConfigInternal,
-	metricsCollector MetricsCollector,
-) ([]*EngineBackend, error) {
-	var engineBackends []*EngineBackend
-
-	for _, engine := range engines {
-		// Create vault config for this engine
-		vaultConfig := &vault.Config{
-			Address:            baseVaultConfig.Address,
-			AuthMethod:         baseVaultConfig.AuthMethod,
-			KubernetesAuthRole: baseVaultConfig.KubernetesAuthRole,
-			KubernetesAuthPath: baseVaultConfig.KubernetesAuthPath,
-			Token:              baseVaultConfig.Token,
-			MountPath:          engine.MountPath,
-			SkipVerify:         baseVaultConfig.SkipVerify,
-			CACert:             baseVaultConfig.CACert,
-		}
+// Error implements the error interface
+func (e *EngineError) Error() string {
+	return fmt.Sprintf("secret engine %s: %v", e.Engine, e.Err)
+}
 
-		// Create backend for this engine
-		backend, err := vault.NewVaultBackend(vaultConfig, metricsCollector)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create backend for engine %s: %w", engine.Name, err)
-		}
+// Unwrap returns the cause of the error
+func (e *EngineError) Unwrap() error {
+	return e.Err
+}
 
-		// Parse sync label (format: "key" or "key=value")
-		syncLabelKey, syncLabelVal := parseSyncLabel(engine.SyncLabel)
+// MatchesLabels checks if the given labels match the sync selector of the engine.
+// Engines with an invalid selector match any labels, so their error is not hidden.
+func (e *EngineError) MatchesLabels(bmcSecretLabels map[string]string) bool {
+	return e.SyncSelector == nil || e.SyncSelector.Matches(labels.Set(bmcSecretLabels))
+}
 
-		// Create path builder
-		pathTemplate := engine.PathTemplate
-		if pathTemplate == "" {
-			pathTemplate = "bmc/{{.Region}}/{{.Hostname}}/{{.Username}}"
-		}
-		pathBuilder, err := NewPathBuilder(pathTemplate)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create path builder for engine %s: %w", engine.Name, err)
+// EngineErrors returns the EngineErrors joined in err
+func EngineErrors(err error) []*EngineError {
+	var engineErrs []*EngineError
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			engineErrs = append(engineErrs, EngineErrors(err)...)
 		}
+		return engineErrs
+	}
+	if engineErr, ok := err.(*EngineError); ok {
+		engineErrs = append(engineErrs, engineErr)
+	}
+	return engineErrs
+}
+
+// newEngineBackend creates the EngineBackend of a secret engine of the configuration,
+// resolving the credentials of the engine on its own. Errors are returned as EngineError.
+func newEngineBackend(
+	ctx context.Context,
+	c client.Client,
+	config *Config,
+	baseConfig *VaultConfigInternal,
+	engine *configv1alpha1.SecretEngineConfig,
+	metricsCollector MetricsCollector,
+) (*EngineBackend, error) {
+	engineErr := &EngineError{Engine: engine.Name}
 
-		engineBackends = append(engineBackends, &EngineBackend{
-			Backend:      backend,
-			EngineName:   engine.Name,
-			PathBuilder:  pathBuilder,
-			SyncLabel:    engine.SyncLabel,
-			SyncLabelKey: syncLabelKey,
-			SyncLabelVal: syncLabelVal,
-		})
+	if engine.SyncSelector == nil && engine.SyncLabel == "" {
+		engineErr.Err = errors.New("requires a syncSelector or syncLabel")
+		return nil, engineErr
+	}
+	syncSelector, err := SyncSelector(engine.SyncSelector, engine.SyncLabel)
+	if err != nil {
+		engineErr.Err = fmt.Errorf("invalid sync selector: %w", err)
+		return nil, engineErr
 	}
+	engineErr.SyncSelector = syncSelector
 
-	return engineBackends, nil
-}
+	// Create path builder
+	pathTemplate := engine.PathTemplate
+	if pathTemplate == "" {
+		pathTemplate = DefaultPathTemplate
+	}
+	pathBuilder, err := NewPathBuilder(pathTemplate)
+	if err != nil {
+		engineErr.Err = fmt.Errorf("failed to create path builder: %w", err)
+		return nil, engineErr
+	}
+
+	// Create backend for this engine, with the address, namespace, auth and TLS of the
+	// base config unless the engine overrides them
+	engineConfig, err := withAuthSecret(ctx, c, baseConfig.engineConfig(engine.Name))
+	if err != nil {
+		engineErr.Err = err
+		return nil, engineErr
+	}
+	backend, err := newKVBackend(config.Backend, engineConfig, KVBackendOptions{
+		MountPath:  engine.MountPath,
+		ConfigName: config.Name,
+		EngineName: engine.Name,
+	}, metricsCollector)
+	if err != nil {
+		engineErr.Err = fmt.Errorf("failed to create backend: %w", err)
+		return nil, engineErr
+	}
 
-// parseSyncLabel parses a sync label into key and value components
-// Format: "key" or "key=value"
-// Returns: (key, value) where value is empty string if format is just "key"
-func parseSyncLabel(syncLabel string) (string, string) {
-	parts := strings.SplitN(syncLabel, "=", 2)
-	if len(parts) == 2 {
-		return parts[0], parts[1]
+	engineConcurrency := config.MaxConcurrentSyncs
+	if engine.MaxConcurrentSyncs != nil && *engine.MaxConcurrentSyncs > 0 {
+		engineConcurrency = int(*engine.MaxConcurrentSyncs)
 	}
-	return parts[0], ""
+
+	engineDeletionPolicy := config.DeletionPolicy
+	if engine.DeletionPolicy != "" {
+		engineDeletionPolicy = engine.DeletionPolicy
+	}
+
+	return &EngineBackend{
+		Backend:     backend,
+		EngineName:  engine.Name,
+		PathBuilder: pathBuilder,
+		Location: Location{
+			Address:   engineConfig.Address,
+			Namespace: engineConfig.Namespace,
+			MountPath: engine.MountPath,
+		},
+		SyncSelector:       syncSelector,
+		MaxConcurrentSyncs: engineConcurrency,
+		DeletionPolicy:     engineDeletionPolicy,
+	}, nil
 }
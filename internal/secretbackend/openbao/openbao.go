@@ -17,50 +17,96 @@ limitations under the License.
 package openbao
 
 import (
-	"context"
 	"fmt"
+
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/vault"
+)
+
+const (
+	backendType = "openbao"
 )
 
+func init() {
+	secretbackend.RegisterKVBackend(backendType, func(
+		config *secretbackend.VaultConfigInternal,
+		opts secretbackend.KVBackendOptions,
+		metricsCollector secretbackend.MetricsCollector,
+	) (secretbackend.Backend, error) {
+		backend, err := NewOpenBaoBackend(&Config{
+			Address:            config.Address,
+			Namespace:          config.Namespace,
+			AuthMethod:         config.AuthMethod,
+			KubernetesAuthRole: config.KubernetesAuthRole,
+			KubernetesAuthPath: config.KubernetesAuthPath,
+			Token:              config.Token,
+			AppRoleRoleID:      config.AppRoleRoleID,
+			AppRoleSecretID:    config.AppRoleSecretID,
+			AppRolePath:        config.AppRolePath,
+			MountPath:          opts.MountPath,
+			SkipVerify:         config.SkipVerify,
+			CACert:             config.CACert,
+			ConfigName:         opts.ConfigName,
+			EngineName:         opts.EngineName,
+		}, metricsCollector)
+		if err != nil {
+			return nil, err
+		}
+		return backend, nil
+	})
+}
+
 // Config holds OpenBao configuration
 type Config struct {
-	Address    string
-	AuthMethod string
-	// OpenBao is Vault-compatible, so configuration will mirror VaultBackend
-	// Additional fields will be added as needed
+	Address            string
+	Namespace          string
+	AuthMethod         string
+	KubernetesAuthRole string
+	KubernetesAuthPath string
+	Token              string
+	AppRoleRoleID      string
+	AppRoleSecretID    string
+	AppRolePath        string
+	MountPath          string
+	SkipVerify         bool
+	CACert             string
+
+	// ConfigName and EngineName identify the backend in the token metrics;
+	// EngineName is empty for the base backend
+	ConfigName string
+	EngineName string
 }
 
 // OpenBaoBackend implements the Backend interface for OpenBao
+// OpenBao keeps the Vault HTTP API (auth methods, sys/mounts and KV v1/v2),
+// so the backend reuses the Vault client implementation and only differs in
+// how it reports itself in metrics
 type OpenBaoBackend struct {
-	// OpenBao is Vault-compatible, so implementation will mirror VaultBackend
-	// Use openbao/openbao/api client library when implementing
+	*vault.VaultBackend
 }
 
 // NewOpenBaoBackend creates a new OpenBao backend
-func NewOpenBaoBackend(config *Config) (*OpenBaoBackend, error) {
-	return nil, fmt.Errorf("OpenBao backend not yet implemented")
-}
-
-// WriteSecret writes a secret to OpenBao
-func (o *OpenBaoBackend) WriteSecret(ctx context.Context, path string, data map[string]any) error {
-	return fmt.Errorf("OpenBao backend not yet implemented")
-}
-
-// ReadSecret reads a secret from OpenBao
-func (o *OpenBaoBackend) ReadSecret(ctx context.Context, path string) (map[string]any, error) {
-	return nil, fmt.Errorf("OpenBao backend not yet implemented")
-}
-
-// DeleteSecret deletes a secret from OpenBao
-func (o *OpenBaoBackend) DeleteSecret(ctx context.Context, path string) error {
-	return fmt.Errorf("OpenBao backend not yet implemented")
-}
-
-// SecretExists checks if a secret exists in OpenBao
-func (o *OpenBaoBackend) SecretExists(ctx context.Context, path string) (bool, error) {
-	return false, fmt.Errorf("OpenBao backend not yet implemented")
-}
+func NewOpenBaoBackend(config *Config, metricsCollector secretbackend.MetricsCollector) (*OpenBaoBackend, error) {
+	backend, err := vault.NewVaultBackend(&vault.Config{
+		Address:            config.Address,
+		Namespace:          config.Namespace,
+		AuthMethod:         config.AuthMethod,
+		KubernetesAuthRole: config.KubernetesAuthRole,
+		KubernetesAuthPath: config.KubernetesAuthPath,
+		Token:              config.Token,
+		AppRoleRoleID:      config.AppRoleRoleID,
+		AppRoleSecretID:    config.AppRoleSecretID,
+		AppRolePath:        config.AppRolePath,
+		MountPath:          config.MountPath,
+		SkipVerify:         config.SkipVerify,
+		CACert:             config.CACert,
+		BackendType:        backendType,
+		ConfigName:         config.ConfigName,
+		EngineName:         config.EngineName,
+	}, metricsCollector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create openbao backend: %w", err)
+	}
 
-// Close closes the OpenBao client
-func (o *OpenBaoBackend) Close() error {
-	return nil
+	return &OpenBaoBackend{VaultBackend: backend}, nil
 }
@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openbao
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/vaulttest"
+)
+
+const testToken = "s.openbao-test-token"
+
+func TestOpenBao(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "OpenBao Backend Suite")
+}
+
+var _ = Describe("OpenBaoBackend", func() {
+	var (
+		ctx    context.Context
+		server *vaulttest.Server
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = vaulttest.NewServer()
+		server.AddToken(testToken)
+		server.AddMount("secret", 2)
+		server.AddMount("kv", 1)
+		DeferCleanup(server.Close)
+	})
+
+	newBackend := func(mountPath string) (*OpenBaoBackend, error) {
+		return NewOpenBaoBackend(&Config{
+			Address:    server.URL,
+			AuthMethod: "token",
+			Token:      testToken,
+			MountPath:  mountPath,
+			CACert:     server.CACert(),
+		}, nil)
+	}
+
+	Context("When using a KV v2 mount", func() {
+		It("Should write, read and delete secrets", func() {
+			backend, err := newBackend("secret")
+			Expect(err).NotTo(HaveOccurred())
+
+			data := map[string]any{"username": "admin", "password": "secret123"}
+			Expect(backend.WriteSecret(ctx, "bmc/us-east-1/bmc1/admin", data, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+
+			stored, ok := server.Secret("secret", "bmc/us-east-1/bmc1/admin")
+			Expect(ok).To(BeTrue())
+			Expect(stored).To(Equal(data))
+
+			read, err := backend.ReadSecret(ctx, "bmc/us-east-1/bmc1/admin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Data).To(Equal(data))
+
+			exists, err := backend.SecretExists(ctx, "bmc/us-east-1/bmc1/admin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			Expect(backend.DeleteSecret(ctx, "bmc/us-east-1/bmc1/admin", secretbackend.DeleteOptions{})).To(Succeed())
+			Expect(server.Requests).To(ContainElement("DELETE /v1/secret/metadata/bmc/us-east-1/bmc1/admin"))
+
+			exists, err = backend.SecretExists(ctx, "bmc/us-east-1/bmc1/admin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+	})
+
+	Context("When using a KV v1 mount", func() {
+		It("Should detect the KV version and use direct paths", func() {
+			backend, err := newBackend("kv")
+			Expect(err).NotTo(HaveOccurred())
+
+			data := map[string]any{"username": "root", "password": "calvin"}
+			Expect(backend.WriteSecret(ctx, "bmc/eu-west-1/bmc2/root", data, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+			Expect(server.Requests).To(ContainElement("PUT /v1/kv/bmc/eu-west-1/bmc2/root"))
+
+			read, err := backend.ReadSecret(ctx, "bmc/eu-west-1/bmc2/root")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Data).To(Equal(data))
+		})
+	})
+
+	Context("When the configuration is invalid", func() {
+		It("Should fail with an invalid token", func() {
+			_, err := NewOpenBaoBackend(&Config{
+				Address:    server.URL,
+				AuthMethod: "token",
+				Token:      "invalid",
+				MountPath:  "secret",
+				CACert:     server.CACert(),
+			}, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("token validation failed"))
+		})
+
+		It("Should fail when the mount does not exist", func() {
+			_, err := newBackend("missing")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("mount missing not found"))
+		})
+
+		It("Should fail when the server certificate is not trusted", func() {
+			_, err := NewOpenBaoBackend(&Config{
+				Address:    server.URL,
+				AuthMethod: "token",
+				Token:      testToken,
+				MountPath:  "secret",
+			}, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Adopt policies for existing backend secrets without an ownership marker
+const (
+	AdoptPolicyRefuse = "Refuse"
+	AdoptPolicyAdopt  = "Adopt"
+)
+
+// Custom metadata keys of the ownership marker
+const (
+	ownerManagedByKey = "managed-by"
+	ownerClusterIDKey = "cluster-id"
+	ownerBMCSecretKey = "bmc-secret"
+	ownerConfigKey    = "secret-backend-config"
+
+	ownerManagedBy = "bmc-secret-operator"
+)
+
+// ErrOwnershipConflict is returned when a backend secret may not be overwritten
+// or deleted because it is not owned by the BMCSecret syncing to it
+var ErrOwnershipConflict = errors.New("ownership conflict")
+
+// Owner identifies the operator instance and BMCSecret a backend secret belongs to
+type Owner struct {
+	ClusterID string
+	BMCSecret string
+
+	// Config is the name of the configuration the secret was written with.
+	// It is informational only, so switching configurations keeps ownership.
+	Config string
+}
+
+// Metadata returns the ownership marker stored as custom metadata with every written secret
+func (o Owner) Metadata() map[string]string {
+	return map[string]string{
+		ownerManagedByKey: ownerManagedBy,
+		ownerClusterIDKey: o.ClusterID,
+		ownerBMCSecretKey: o.BMCSecret,
+		ownerConfigKey:    o.Config,
+	}
+}
+
+// Matches reports whether o and other are the same cluster and BMCSecret,
+// regardless of the configuration they were written with
+func (o Owner) Matches(other Owner) bool {
+	return o.ClusterID == other.ClusterID && o.BMCSecret == other.BMCSecret
+}
+
+// OwnerFromMetadata returns the owner recorded in the custom metadata of a
+// backend secret, and false if the secret carries no ownership marker
+func OwnerFromMetadata(metadata map[string]string) (Owner, bool) {
+	if metadata[ownerManagedByKey] != ownerManagedBy {
+		return Owner{}, false
+	}
+	return Owner{
+		ClusterID: metadata[ownerClusterIDKey],
+		BMCSecret: metadata[ownerBMCSecretKey],
+		Config:    metadata[ownerConfigKey],
+	}, true
+}
+
+// CheckOwnership returns an error wrapping ErrOwnershipConflict if owner may not
+// overwrite or delete the existing secret. Secrets marked as owned by another
+// cluster or BMCSecret are never modified, secrets without a marker only with
+// the Adopt policy.
+func CheckOwnership(secret *Secret, owner Owner, adoptPolicy string) error {
+	current, marked := OwnerFromMetadata(secret.Metadata)
+	if !marked {
+		if adoptPolicy == AdoptPolicyAdopt {
+			return nil
+		}
+		return fmt.Errorf("%w: secret was not written by the operator and the adopt policy is %s", ErrOwnershipConflict, adoptPolicy)
+	}
+
+	if !current.Matches(owner) {
+		return fmt.Errorf("%w: secret is owned by BMCSecret %q in cluster %q", ErrOwnershipConflict, current.BMCSecret, current.ClusterID)
+	}
+	return nil
+}
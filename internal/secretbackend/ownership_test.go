@@ -0,0 +1,64 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ownership", func() {
+	owner := Owner{ClusterID: "cluster-a", BMCSecret: "shared", Config: DefaultBackendConfigName}
+
+	It("Should read back the owner from its metadata", func() {
+		recorded, ok := OwnerFromMetadata(owner.Metadata())
+		Expect(ok).To(BeTrue())
+		Expect(recorded).To(Equal(owner))
+	})
+
+	It("Should not find an owner without the marker", func() {
+		_, ok := OwnerFromMetadata(map[string]string{"bmc-secret": "shared"})
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Should allow secrets owned by the same cluster and BMCSecret", func() {
+		metadata := Owner{ClusterID: "cluster-a", BMCSecret: "shared", Config: "other-config"}.Metadata()
+		Expect(CheckOwnership(&Secret{Metadata: metadata}, owner, AdoptPolicyRefuse)).To(Succeed())
+	})
+
+	It("Should match owners regardless of the config", func() {
+		Expect(owner.Matches(Owner{ClusterID: "cluster-a", BMCSecret: "shared", Config: "other-config"})).To(BeTrue())
+		Expect(owner.Matches(Owner{ClusterID: "cluster-a", BMCSecret: "other", Config: DefaultBackendConfigName})).To(BeFalse())
+	})
+
+	It("Should refuse secrets owned by another BMCSecret or cluster", func() {
+		for _, other := range []Owner{
+			{ClusterID: "cluster-a", BMCSecret: "other"},
+			{ClusterID: "cluster-b", BMCSecret: "shared"},
+		} {
+			err := CheckOwnership(&Secret{Metadata: other.Metadata()}, owner, AdoptPolicyAdopt)
+			Expect(err).To(MatchError(ErrOwnershipConflict))
+		}
+	})
+
+	It("Should only adopt secrets without a marker with the Adopt policy", func() {
+		secret := &Secret{Data: map[string]any{"username": "admin"}}
+
+		Expect(CheckOwnership(secret, owner, AdoptPolicyRefuse)).To(MatchError(ErrOwnershipConflict))
+		Expect(CheckOwnership(secret, owner, AdoptPolicyAdopt)).To(Succeed())
+	})
+})
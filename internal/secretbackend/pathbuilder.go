@@ -18,10 +18,20 @@ package secretbackend
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"text/template"
 )
 
+// ErrInvalidPath is returned when a rendered path cannot be used as a secret path
+var ErrInvalidPath = errors.New("invalid secret path")
+
+// pathSegmentPattern matches the path segments Vault and OpenBao accept without
+// escaping. The glob characters of ACL policies, + and *, are not allowed.
+var pathSegmentPattern = regexp.MustCompile(`^[A-Za-z0-9._~:@=,-]+$`)
+
 // PathBuilder builds secret paths from templates
 type PathBuilder struct {
 	template *template.Template
@@ -32,11 +42,55 @@ type PathVariables struct {
 	Region   string
 	Hostname string
 	Username string
+
+	// BMCName is the name of the BMC resource
+	BMCName string
+	// SecretName is the name of the BMCSecret resource
+	SecretName string
+	// Labels and Annotations are the labels and annotations of the BMC
+	Labels      map[string]string
+	Annotations map[string]string
+	// Protocol and Port are the protocol name and port the BMC is accessed with
+	Protocol string
+	Port     int32
+	// MACAddress and IP are the address of the BMC, empty when unknown
+	MACAddress string
+	IP         string
+}
+
+// pathFuncs are the functions available in path templates. Arguments are
+// ordered so that the value can be piped in, as in {{.Hostname | trimSuffix ".example.com"}}
+var pathFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"replace": func(old, replacement, s string) string {
+		return strings.ReplaceAll(s, old, replacement)
+	},
+	"trimSuffix": func(suffix, s string) string {
+		return strings.TrimSuffix(s, suffix)
+	},
+	"split": func(sep, s string) []string {
+		return strings.Split(s, sep)
+	},
+	"default": func(def, s string) string {
+		if s == "" {
+			return def
+		}
+		return s
+	},
+	"regexReplace": func(pattern, replacement, s string) (string, error) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+		}
+		return re.ReplaceAllString(s, replacement), nil
+	},
 }
 
 // NewPathBuilder creates a new PathBuilder with the given template string
 func NewPathBuilder(templateStr string) (*PathBuilder, error) {
-	tmpl, err := template.New("path").Parse(templateStr)
+	// Missing labels and annotations render as empty strings instead of "<no value>"
+	tmpl, err := template.New("path").Funcs(pathFuncs).Option("missingkey=zero").Parse(templateStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse path template: %w", err)
 	}
@@ -46,11 +100,37 @@ func NewPathBuilder(templateStr string) (*PathBuilder, error) {
 	}, nil
 }
 
-// Build constructs a path using the provided variables
+// Build constructs a path using the provided variables. The rendered path is
+// normalized with NormalizePath.
 func (pb *PathBuilder) Build(vars PathVariables) (string, error) {
 	var buf bytes.Buffer
 	if err := pb.template.Execute(&buf, vars); err != nil {
 		return "", fmt.Errorf("failed to execute path template: %w", err)
 	}
-	return buf.String(), nil
+	return NormalizePath(buf.String())
+}
+
+// NormalizePath drops empty segments and the whitespace around segments, so
+// variables that render empty don't leave double or trailing slashes. It returns
+// an error wrapping ErrInvalidPath if the path is empty or a segment is . or ..
+// or contains characters other than letters, digits and -._~:@=,
+func NormalizePath(path string) (string, error) {
+	var segments []string
+	for segment := range strings.SplitSeq(path, "/") {
+		segment = strings.TrimSpace(segment)
+		switch {
+		case segment == "":
+			continue
+		case segment == "." || segment == "..":
+			return "", fmt.Errorf("%w %q: segment %q is not allowed", ErrInvalidPath, path, segment)
+		case !pathSegmentPattern.MatchString(segment):
+			return "", fmt.Errorf("%w %q: segment %q may only contain letters, digits and -._~:@=,", ErrInvalidPath, path, segment)
+		}
+		segments = append(segments, segment)
+	}
+
+	if len(segments) == 0 {
+		return "", fmt.Errorf("%w: the path is empty", ErrInvalidPath)
+	}
+	return strings.Join(segments, "/"), nil
 }
@@ -87,7 +87,7 @@ var _ = Describe("PathBuilder", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
-		It("Should handle empty variable values", func() {
+		It("Should drop the segments of empty variable values", func() {
 			builder, err := NewPathBuilder("bmc/{{.Region}}/{{.Hostname}}/{{.Username}}")
 			Expect(err).NotTo(HaveOccurred())
 
@@ -97,7 +97,7 @@ var _ = Describe("PathBuilder", func() {
 				Username: "admin",
 			})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(path).To(Equal("bmc//bmc-server1.example.com/admin"))
+			Expect(path).To(Equal("bmc/bmc-server1.example.com/admin"))
 		})
 
 		It("Should build path without username", func() {
@@ -125,4 +125,167 @@ var _ = Describe("PathBuilder", func() {
 			Expect(path).To(Equal("infrastructure/datacenters/eu-central-1/hardware/bmc/dc01-bmc05.example.com/credentials/ipmi-admin"))
 		})
 	})
+
+	Context("When using BMC variables", func() {
+		vars := PathVariables{
+			Region:      "us-east-1",
+			Hostname:    "bmc01.dc.example.com",
+			Username:    "admin",
+			BMCName:     "server-01-bmc",
+			SecretName:  "server-01-credentials",
+			Labels:      map[string]string{"rack": "r12", "topology.kubernetes.io/zone": "zone-a"},
+			Annotations: map[string]string{"owner": "team-infra"},
+			Protocol:    "Redfish",
+			Port:        443,
+			MACAddress:  "aa:bb:cc:dd:ee:ff",
+			IP:          "10.0.0.1",
+		}
+
+		It("Should expose the BMC and BMCSecret names", func() {
+			builder, err := NewPathBuilder("bmc/{{.BMCName}}/{{.SecretName}}")
+			Expect(err).NotTo(HaveOccurred())
+
+			path, err := builder.Build(vars)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("bmc/server-01-bmc/server-01-credentials"))
+		})
+
+		It("Should expose labels and annotations", func() {
+			builder, err := NewPathBuilder(`bmc/{{.Labels.rack}}/{{index .Labels "topology.kubernetes.io/zone"}}/{{.Annotations.owner}}`)
+			Expect(err).NotTo(HaveOccurred())
+
+			path, err := builder.Build(vars)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("bmc/r12/zone-a/team-infra"))
+		})
+
+		It("Should render missing labels as empty strings", func() {
+			builder, err := NewPathBuilder("bmc/{{.Labels.missing}}/{{.Hostname}}")
+			Expect(err).NotTo(HaveOccurred())
+
+			path, err := builder.Build(vars)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("bmc/bmc01.dc.example.com"))
+
+			path, err = builder.Build(PathVariables{Hostname: "bmc01.dc.example.com"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("bmc/bmc01.dc.example.com"))
+		})
+
+		It("Should expose the protocol and address", func() {
+			builder, err := NewPathBuilder("bmc/{{.Protocol}}/{{.Port}}/{{.MACAddress}}/{{.IP}}")
+			Expect(err).NotTo(HaveOccurred())
+
+			path, err := builder.Build(vars)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("bmc/Redfish/443/aa:bb:cc:dd:ee:ff/10.0.0.1"))
+		})
+	})
+
+	Context("When using template functions", func() {
+		vars := PathVariables{
+			Region:     "US-East-1",
+			Hostname:   "bmc01.dc.example.com",
+			Username:   "Admin",
+			MACAddress: "AA:BB:CC:DD:EE:FF",
+		}
+
+		build := func(templateStr string, vars PathVariables) string {
+			builder, err := NewPathBuilder(templateStr)
+			Expect(err).NotTo(HaveOccurred())
+			path, err := builder.Build(vars)
+			Expect(err).NotTo(HaveOccurred())
+			return path
+		}
+
+		It("Should change the case", func() {
+			Expect(build("bmc/{{.Region | lower}}/{{upper .Username}}", vars)).To(Equal("bmc/us-east-1/ADMIN"))
+		})
+
+		It("Should replace substrings", func() {
+			Expect(build(`bmc/{{.MACAddress | replace ":" "-" | lower}}`, vars)).To(Equal("bmc/aa-bb-cc-dd-ee-ff"))
+		})
+
+		It("Should trim suffixes", func() {
+			Expect(build(`bmc/{{.Hostname | trimSuffix ".example.com"}}`, vars)).To(Equal("bmc/bmc01.dc"))
+			Expect(build(`bmc/{{.Hostname | trimSuffix ".example.org"}}`, vars)).To(Equal("bmc/bmc01.dc.example.com"))
+		})
+
+		It("Should split values and index the parts", func() {
+			Expect(build(`bmc/{{index (split "." .Hostname) 1}}/{{index (split "." .Hostname) 0}}`, vars)).To(Equal("bmc/dc/bmc01"))
+		})
+
+		It("Should fail when indexing a missing part", func() {
+			builder, err := NewPathBuilder(`bmc/{{index (split "." .Region) 1}}`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = builder.Build(vars)
+			Expect(err).To(MatchError(ContainSubstring("index out of range")))
+		})
+
+		It("Should fall back to defaults for empty values", func() {
+			Expect(build(`bmc/{{.Labels.rack | default "no-rack"}}/{{.Region | default "global"}}`, vars)).To(Equal("bmc/no-rack/US-East-1"))
+		})
+
+		It("Should replace regular expressions", func() {
+			Expect(build(`bmc/{{.Hostname | regexReplace "^([^.]+)\\..*$" "$1"}}`, vars)).To(Equal("bmc/bmc01"))
+		})
+
+		It("Should fail on invalid regular expressions", func() {
+			builder, err := NewPathBuilder(`bmc/{{.Hostname | regexReplace "(" ""}}`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = builder.Build(vars)
+			Expect(err).To(MatchError(ContainSubstring("invalid regular expression")))
+		})
+
+		It("Should reject unknown functions", func() {
+			_, err := NewPathBuilder("bmc/{{.Hostname | env}}")
+			Expect(err).To(MatchError(ContainSubstring(`function "env" not defined`)))
+		})
+	})
+
+	Context("When normalizing paths", func() {
+		It("Should drop empty segments and surrounding whitespace", func() {
+			Expect(NormalizePath("/bmc//us-east-1/ bmc1 /admin/")).To(Equal("bmc/us-east-1/bmc1/admin"))
+		})
+
+		It("Should normalize rendered paths", func() {
+			builder, err := NewPathBuilder("/bmc/{{.Region}}/{{.Labels.rack}}/{{.Hostname}}/")
+			Expect(err).NotTo(HaveOccurred())
+
+			path, err := builder.Build(PathVariables{Region: "us-east-1", Hostname: "bmc1.example.com"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("bmc/us-east-1/bmc1.example.com"))
+		})
+
+		It("Should reject empty paths", func() {
+			_, err := NormalizePath(" / // ")
+			Expect(err).To(MatchError(ErrInvalidPath))
+			Expect(err).To(MatchError(ContainSubstring("the path is empty")))
+		})
+
+		It("Should reject relative segments", func() {
+			builder, err := NewPathBuilder("bmc/{{.Region}}/{{.Hostname}}")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = builder.Build(PathVariables{Region: "..", Hostname: "bmc1.example.com"})
+			Expect(err).To(MatchError(ErrInvalidPath))
+			Expect(err).To(MatchError(ContainSubstring(`segment ".." is not allowed`)))
+
+			_, err = NormalizePath("bmc/./bmc1")
+			Expect(err).To(MatchError(ErrInvalidPath))
+		})
+
+		It("Should reject spaces and characters Vault rejects", func() {
+			for _, path := range []string{"bmc/us east/bmc1", "bmc/+/bmc1", "bmc/bmc*", "bmc/bmc1?version=2", "bmc/bmc1#admin", "bmc/bmc\\1"} {
+				_, err := NormalizePath(path)
+				Expect(err).To(MatchError(ErrInvalidPath), path)
+			}
+		})
+
+		It("Should accept MAC and IP addresses", func() {
+			Expect(NormalizePath("bmc/aa:bb:cc:dd:ee:ff/fd00::1/10.0.0.1")).To(Equal("bmc/aa:bb:cc:dd:ee:ff/fd00::1/10.0.0.1"))
+		})
+	})
 })
@@ -0,0 +1,109 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend
+
+import (
+	"context"
+	"errors"
+
+	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrCredentialsUnavailable is returned in a ProbeResult when the Kubernetes
+// secret holding the credentials of the auth method cannot be resolved
+var ErrCredentialsUnavailable = errors.New("credentials unavailable")
+
+// ProbeResult is the outcome of connecting to a backend of a SecretBackendConfig
+type ProbeResult struct {
+	// Engine is the secret engine name, empty for the base backend
+	Engine string
+
+	// MountPath is the KV mount path that was looked up
+	MountPath string
+
+	// Err is why the backend cannot be used, nil if authentication and mount
+	// detection succeeded. It wraps ErrCredentialsUnavailable, ErrAuthentication
+	// or ErrMountDetection when one of these steps failed.
+	Err error
+}
+
+// BackendProber checks that the backends of a SecretBackendConfig can be used
+type BackendProber struct {
+	client           client.Client
+	metricsCollector MetricsCollector
+}
+
+// NewBackendProber creates a new backend prober
+func NewBackendProber(c client.Client, metricsCollector MetricsCollector) *BackendProber {
+	return &BackendProber{
+		client:           c,
+		metricsCollector: metricsCollector,
+	}
+}
+
+// Probe validates the configuration, then authenticates with the base backend and
+// every secret engine and detects their KV mounts. Each backend is probed with a
+// client of its own that is closed afterwards, revoking the token it logged in with,
+// so the backends cached by the BackendFactory are not affected. An error is only returned for an invalid
+// configuration; failures to connect are reported per backend in the results,
+// the base backend first and then the engines in the order configured.
+func (p *BackendProber) Probe(ctx context.Context, crdConfig *configv1alpha1.SecretBackendConfig) ([]ProbeResult, error) {
+	if err := ValidateConfig(crdConfig); err != nil {
+		return nil, err
+	}
+	config, err := LoadConfigFromCRD(crdConfig)
+	if err != nil {
+		return nil, err
+	}
+	kvConfig := config.kvConfig()
+
+	results := []ProbeResult{{
+		MountPath: kvConfig.MountPath,
+		Err: p.probe(ctx, config.Backend, kvConfig, KVBackendOptions{
+			MountPath:  kvConfig.MountPath,
+			ConfigName: config.Name,
+		}),
+	}}
+	for _, engine := range secretEngines(&crdConfig.Spec) {
+		results = append(results, ProbeResult{
+			Engine:    engine.Name,
+			MountPath: engine.MountPath,
+			Err: p.probe(ctx, config.Backend, kvConfig.engineConfig(engine.Name), KVBackendOptions{
+				MountPath:  engine.MountPath,
+				ConfigName: config.Name,
+				EngineName: engine.Name,
+			}),
+		})
+	}
+
+	return results, nil
+}
+
+// probe resolves the credentials of a single configuration and creates a backend with them
+func (p *BackendProber) probe(ctx context.Context, backendType string, kvConfig *VaultConfigInternal, opts KVBackendOptions) error {
+	resolved, err := withAuthSecret(ctx, p.client, kvConfig)
+	if err != nil {
+		return err
+	}
+
+	backend, err := newKVBackend(backendType, resolved, opts, p.metricsCollector)
+	if err != nil {
+		return err
+	}
+	return backend.Close()
+}
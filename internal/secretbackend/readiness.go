@@ -0,0 +1,234 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultReadinessInterval is how often the backends are checked for readiness by default
+	DefaultReadinessInterval = 30 * time.Second
+
+	// readinessCheckTimeout bounds a single round of backend checks
+	readinessCheckTimeout = 10 * time.Second
+)
+
+// BackendHealth is the readiness of a single backend
+type BackendHealth struct {
+	// Config is the name of the configuration, EnvConfigName for environment variables
+	Config string
+
+	// Engine is the secret engine name, empty for the base backend
+	Engine string
+
+	// Err is why the backend is not ready, nil if it is reachable and authenticated
+	Err error
+}
+
+// Name identifies the backend as config or config/engine
+func (h BackendHealth) Name() string {
+	if h.Engine == "" {
+		return h.Config
+	}
+	return h.Config + "/" + h.Engine
+}
+
+// ReadinessChecker checks in the background that the backends of every configuration
+// are reachable and authenticated, so readiness probes only read the cached outcome.
+// Configurations with secret engines are checked per engine, as their base backend
+// is not used for syncing.
+type ReadinessChecker struct {
+	registry BackendRegistryInterface
+	interval time.Duration
+
+	mu      sync.RWMutex
+	results []BackendHealth
+	checked bool
+}
+
+// NewReadinessChecker creates a readiness checker checking the backends every interval
+func NewReadinessChecker(registry BackendRegistryInterface, interval time.Duration) *ReadinessChecker {
+	if interval <= 0 {
+		interval = DefaultReadinessInterval
+	}
+	return &ReadinessChecker{
+		registry: registry,
+		interval: interval,
+	}
+}
+
+// Start checks the backends every interval until the context is done
+func (c *ReadinessChecker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection reports that every replica checks the backends, as each
+// one reports its own readiness
+func (c *ReadinessChecker) NeedLeaderElection() bool {
+	return false
+}
+
+// Refresh checks the backends of every configuration and caches the outcome
+func (c *ReadinessChecker) Refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	results := c.checkBackends(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = results
+	c.checked = true
+}
+
+// Results returns the outcome of the last check and whether a check completed yet
+func (c *ReadinessChecker) Results() ([]BackendHealth, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.results, c.checked
+}
+
+// Check implements healthz.Checker. It fails until the first check completed and
+// while any backend is not ready.
+func (c *ReadinessChecker) Check(_ *http.Request) error {
+	results, checked := c.Results()
+	if !checked {
+		return errors.New("secret backends not checked yet")
+	}
+
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Name())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("secret backends not ready: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// ServeHTTP reports the readiness of every backend, including why a backend is
+// not ready, in the text format of the health probe endpoints
+func (c *ReadinessChecker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	results, checked := c.Results()
+	failed := !checked || slices.ContainsFunc(results, func(result BackendHealth) bool {
+		return result.Err != nil
+	})
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if failed {
+		w.WriteHeader(http.StatusInternalServerError)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !checked {
+		fmt.Fprint(w, "[-]secret backends not checked yet\n")
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(w, "[-]%s failed: %v\n", result.Name(), result.Err)
+		} else {
+			fmt.Fprintf(w, "[+]%s ok\n", result.Name())
+		}
+	}
+
+	if failed {
+		fmt.Fprint(w, "readyz check failed\n")
+	} else {
+		fmt.Fprint(w, "readyz check passed\n")
+	}
+}
+
+// checkBackends checks the backends of every configuration, ordered by config name
+func (c *ReadinessChecker) checkBackends(ctx context.Context) []BackendHealth {
+	factories, err := c.registry.GetFactories(ctx)
+	if err != nil {
+		return []BackendHealth{{Config: "secretbackendconfigs", Err: err}}
+	}
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var results []BackendHealth
+	for _, name := range names {
+		results = append(results, checkFactory(ctx, name, factories[name])...)
+	}
+	return results
+}
+
+// checkFactory checks the base backend of a configuration, or its engine backends
+// if it has secret engines. Backends are created through the factory, so the check
+// reuses and warms up the backends used for syncing.
+func checkFactory(ctx context.Context, name string, factory BackendFactoryInterface) []BackendHealth {
+	hasEngines, err := factory.HasMultiEngineConfig(ctx)
+	if err != nil {
+		return []BackendHealth{{Config: name, Err: err}}
+	}
+
+	if !hasEngines {
+		backend, err := factory.GetBackend(ctx)
+		if err == nil {
+			err = backend.CheckHealth(ctx)
+		}
+		return []BackendHealth{{Config: name, Err: err}}
+	}
+
+	// Engines whose backend cannot be created are reported on their own,
+	// the others are checked regardless
+	engineBackends, err := factory.GetAllEngineBackends(ctx)
+	engineErrs := EngineErrors(err)
+	if err != nil && len(engineErrs) == 0 {
+		return []BackendHealth{{Config: name, Err: err}}
+	}
+	results := make([]BackendHealth, 0, len(engineBackends)+len(engineErrs))
+	for _, engineBackend := range engineBackends {
+		results = append(results, BackendHealth{
+			Config: name,
+			Engine: engineBackend.EngineName,
+			Err:    engineBackend.Backend.CheckHealth(ctx),
+		})
+	}
+	for _, engineErr := range engineErrs {
+		results = append(results, BackendHealth{Config: name, Engine: engineErr.Engine, Err: engineErr})
+	}
+	return results
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend_test
+
+import (
+	"context"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
+	_ "github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/vault"
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/vaulttest"
+)
+
+var _ = Describe("ReadinessChecker", func() {
+	var (
+		ctx           context.Context
+		server        *vaulttest.Server
+		backendConfig *configv1alpha1.SecretBackendConfig
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = vaulttest.NewServer()
+		server.AddMount("secret", 2)
+		server.AddMount("team-a", 2)
+		server.AddToken("hvs.token")
+		DeferCleanup(server.Close)
+
+		backendConfig = &configv1alpha1.SecretBackendConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault-eu"},
+			Spec: configv1alpha1.SecretBackendConfigSpec{
+				Backend: "vault",
+				VaultConfig: &configv1alpha1.VaultConfig{
+					Address:    server.URL,
+					AuthMethod: "token",
+					TokenAuth: &configv1alpha1.TokenAuthConfig{
+						SecretRef: configv1alpha1.SecretReference{
+							Name:      "vault-token",
+							Namespace: "bmc-secret-operator-system",
+							Key:       "token",
+						},
+					},
+					TLSConfig: &configv1alpha1.TLSConfig{CACert: server.CACert()},
+				},
+			},
+		}
+	})
+
+	newChecker := func() *secretbackend.ReadinessChecker {
+		scheme := runtime.NewScheme()
+		Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		registry, err := secretbackend.NewBackendRegistry(fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(backendConfig, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "bmc-secret-operator-system"},
+				Data:       map[string][]byte{"token": []byte("hvs.token")},
+			}).
+			Build(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(registry.Close)
+
+		return secretbackend.NewReadinessChecker(registry, 0)
+	}
+
+	verbose := func(checker *secretbackend.ReadinessChecker) (int, string) {
+		recorder := httptest.NewRecorder()
+		checker.ServeHTTP(recorder, httptest.NewRequest("GET", "/readyz/verbose", nil))
+		return recorder.Code, recorder.Body.String()
+	}
+
+	It("Should not be ready before the backends were checked", func() {
+		checker := newChecker()
+
+		Expect(checker.Check(nil)).To(MatchError("secret backends not checked yet"))
+		code, body := verbose(checker)
+		Expect(code).To(Equal(500))
+		Expect(body).To(ContainSubstring("[-]secret backends not checked yet"))
+	})
+
+	It("Should be ready when the backend is reachable and authenticated", func() {
+		checker := newChecker()
+		checker.Refresh(ctx)
+
+		Expect(checker.Check(nil)).To(Succeed())
+		code, body := verbose(checker)
+		Expect(code).To(Equal(200))
+		Expect(body).To(Equal("[+]vault-eu ok\nreadyz check passed\n"))
+	})
+
+	It("Should answer probes from the last check without contacting the backend", func() {
+		checker := newChecker()
+		checker.Refresh(ctx)
+		lookups := server.Count("GET /v1/auth/token/lookup-self")
+
+		for range 3 {
+			Expect(checker.Check(nil)).To(Succeed())
+		}
+		Expect(server.Count("GET /v1/auth/token/lookup-self")).To(Equal(lookups))
+
+		// The next check reuses the cached backend and only looks up the token
+		checker.Refresh(ctx)
+		Expect(server.Count("GET /v1/auth/token/lookup-self")).To(Equal(lookups + 1))
+	})
+
+	It("Should not be ready once the backend rejects the token", func() {
+		checker := newChecker()
+		checker.Refresh(ctx)
+		Expect(checker.Check(nil)).To(Succeed())
+
+		server.RevokeToken("hvs.token")
+		checker.Refresh(ctx)
+
+		Expect(checker.Check(nil)).To(MatchError("secret backends not ready: vault-eu"))
+		code, body := verbose(checker)
+		Expect(code).To(Equal(500))
+		Expect(body).To(ContainSubstring("[-]vault-eu failed: failed to authenticate"))
+		Expect(body).To(HaveSuffix("readyz check failed\n"))
+	})
+
+	It("Should report every secret engine", func() {
+		backendConfig.Spec.VaultConfig.SecretEngines = []configv1alpha1.SecretEngineConfig{
+			{Name: "team-a", MountPath: "team-a", SyncLabel: "team=a"},
+			{Name: "team-b", MountPath: "secret", SyncLabel: "team=b"},
+		}
+		checker := newChecker()
+		checker.Refresh(ctx)
+
+		code, body := verbose(checker)
+		Expect(code).To(Equal(200))
+		Expect(body).To(Equal("[+]vault-eu/team-a ok\n[+]vault-eu/team-b ok\nreadyz check passed\n"))
+	})
+
+	It("Should name the secret engine that cannot be created", func() {
+		backendConfig.Spec.VaultConfig.SecretEngines = []configv1alpha1.SecretEngineConfig{
+			{Name: "team-a", MountPath: "team-a", SyncLabel: "team=a"},
+			{Name: "team-b", MountPath: "missing", SyncLabel: "team=b"},
+		}
+		checker := newChecker()
+		checker.Refresh(ctx)
+
+		Expect(checker.Check(nil)).To(MatchError("secret backends not ready: vault-eu/team-b"))
+		_, body := verbose(checker)
+		Expect(body).To(ContainSubstring("[+]vault-eu/team-a ok\n"))
+		Expect(body).To(ContainSubstring("[-]vault-eu/team-b failed: secret engine team-b: failed to create backend"))
+
+		// The failed engine is created on the next check, without recreating the other one
+		server.AddMount("missing", 2)
+		logins := server.Count("GET /v1/auth/token/lookup-self")
+		checker.Refresh(ctx)
+
+		Expect(checker.Check(nil)).To(Succeed())
+		Expect(server.Count("GET /v1/auth/token/lookup-self")).To(Equal(logins + 3))
+	})
+})
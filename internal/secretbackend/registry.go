@@ -0,0 +1,194 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BackendRegistry manages one backend factory per SecretBackendConfig
+type BackendRegistry struct {
+	client           client.Client
+	metricsCollector MetricsCollector
+	factories        map[string]*BackendFactory
+	// selectors caches the sync selector of every SecretBackendConfig by the
+	// generation it was parsed from
+	selectors map[string]cachedSelector
+	mu        sync.Mutex
+}
+
+// cachedSelector is the sync selector parsed from a generation of a SecretBackendConfig,
+// nil if the selector is invalid
+type cachedSelector struct {
+	generation int64
+	selector   labels.Selector
+}
+
+// NewBackendRegistry creates a new backend registry
+func NewBackendRegistry(c client.Client, metricsCollector MetricsCollector) (*BackendRegistry, error) {
+	return &BackendRegistry{
+		client:           c,
+		metricsCollector: metricsCollector,
+		factories:        make(map[string]*BackendFactory),
+		selectors:        make(map[string]cachedSelector),
+	}, nil
+}
+
+// GetFactories returns the backend factory of every SecretBackendConfig by config name.
+// Without any SecretBackendConfig, a single factory reading the configuration from
+// environment variables is returned under EnvConfigName.
+func (r *BackendRegistry) GetFactories(ctx context.Context) (map[string]BackendFactoryInterface, error) {
+	var configs configv1alpha1.SecretBackendConfigList
+	if err := r.client.List(ctx, &configs); err != nil {
+		return nil, fmt.Errorf("failed to list SecretBackendConfigs: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	factories := make(map[string]BackendFactoryInterface, max(len(configs.Items), 1))
+	if len(configs.Items) == 0 {
+		factories[EnvConfigName] = r.factory(EnvConfigName, "")
+		return factories, nil
+	}
+
+	for _, config := range configs.Items {
+		factories[config.Name] = r.factory(config.Name, config.Name)
+	}
+	return factories, nil
+}
+
+// SyncSelectors returns the sync selector of every SecretBackendConfig by config name.
+// The selectors are parsed from the listed configs rather than by loading their
+// configuration, so neither credentials nor referenced Secrets are read, and cached per
+// config generation. Invalid selectors are cached as nil as well, so they are not parsed
+// again until the config changes. Without any SecretBackendConfig, the selector of the
+// environment configuration is returned under EnvConfigName.
+func (r *BackendRegistry) SyncSelectors(ctx context.Context) (map[string]labels.Selector, error) {
+	var configs configv1alpha1.SecretBackendConfigList
+	if err := r.client.List(ctx, &configs); err != nil {
+		return nil, fmt.Errorf("failed to list SecretBackendConfigs: %w", err)
+	}
+
+	if len(configs.Items) == 0 {
+		selector, err := SyncSelector(nil, os.Getenv("SYNC_LABEL"))
+		if err != nil {
+			selector = nil
+		}
+		return map[string]labels.Selector{EnvConfigName: selector}, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cache := make(map[string]cachedSelector, len(configs.Items))
+	selectors := make(map[string]labels.Selector, len(configs.Items))
+	for _, config := range configs.Items {
+		cached, ok := r.selectors[config.Name]
+		if !ok || cached.generation != config.Generation {
+			selector, err := SyncSelector(config.Spec.SyncSelector, config.Spec.SyncLabel)
+			if err != nil {
+				selector = nil
+			}
+			cached = cachedSelector{generation: config.Generation, selector: selector}
+		}
+		cache[config.Name] = cached
+		selectors[config.Name] = cached.selector
+	}
+	r.selectors = cache
+	return selectors, nil
+}
+
+// factory returns the cached factory registered under name, creating it if necessary
+func (r *BackendRegistry) factory(name, configName string) *BackendFactory {
+	if factory, ok := r.factories[name]; ok {
+		return factory
+	}
+
+	factory, _ := NewConfigBackendFactory(r.client, r.metricsCollector, configName)
+	r.factories[name] = factory
+	return factory
+}
+
+// InvalidateCache resets the factory of the named SecretBackendConfig, so its next use
+// reloads the configuration. The factory of the environment configuration is reset as
+// well, as it only applies without SecretBackendConfigs. Factories are reset in place
+// rather than dropped: reconciles still holding one then create their backends in the
+// registered factory, where they are closed by the next reset or Close.
+func (r *BackendRegistry) InvalidateCache(configName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for _, name := range []string{configName, EnvConfigName} {
+		factory, ok := r.factories[name]
+		if !ok {
+			continue
+		}
+		if err := factory.InvalidateCache(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to invalidate backend cache of %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RemoveFactory drops the factory of a deleted SecretBackendConfig and closes its
+// backends, so its authenticated clients and token renewers do not outlive the config.
+// The factory of the environment configuration is reset as well, as it applies again
+// once the last SecretBackendConfig is gone. Reconciles still holding the dropped
+// factory fail to load its configuration, as the SecretBackendConfig no longer exists.
+func (r *BackendRegistry) RemoveFactory(configName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	if factory, ok := r.factories[configName]; ok {
+		delete(r.factories, configName)
+		if err := factory.InvalidateCache(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close backend of %s: %w", configName, err))
+		}
+	}
+	if factory, ok := r.factories[EnvConfigName]; ok {
+		if err := factory.InvalidateCache(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to invalidate backend cache of %s: %w", EnvConfigName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the backends of all configurations
+func (r *BackendRegistry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for name, factory := range r.factories {
+		if err := factory.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close backend of %s: %w", name, err))
+		}
+	}
+	r.factories = make(map[string]*BackendFactory)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrSecretKeyNotFound is returned when a referenced secret exists but does
+// not contain the referenced key
+var ErrSecretKeyNotFound = errors.New("secret key not found")
+
+// ResolveSecretReference reads the value referenced by ref from a Kubernetes secret
+func ResolveSecretReference(ctx context.Context, c client.Client, ref *configv1alpha1.SecretReference) (string, error) {
+	var secret corev1.Secret
+	if err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, &secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok || len(value) == 0 {
+		return "", fmt.Errorf("%w: key %s in secret %s/%s", ErrSecretKeyNotFound, ref.Key, ref.Namespace, ref.Name)
+	}
+
+	return string(value), nil
+}
+
+// AuthSecretReferences returns the Kubernetes secrets referenced by the auth
+// method of the selected backend
+func AuthSecretReferences(crdConfig *configv1alpha1.SecretBackendConfig) []configv1alpha1.SecretReference {
+	config, err := LoadConfigFromCRD(crdConfig)
+	if err != nil {
+		return nil
+	}
+
+	kvConfig := config.kvConfig()
+	if kvConfig == nil {
+		return nil
+	}
+
+	var refs []configv1alpha1.SecretReference
+	for _, c := range kvConfig.authConfigs() {
+		ref := c.authSecretRef()
+		if ref != nil && !slices.Contains(refs, *ref) {
+			refs = append(refs, *ref)
+		}
+	}
+	return refs
+}
+
+// SecretReferences returns the Kubernetes secrets referenced by the configuration:
+// those of the auth method of the selected backend and the content hash key
+func SecretReferences(crdConfig *configv1alpha1.SecretBackendConfig) []configv1alpha1.SecretReference {
+	refs := AuthSecretReferences(crdConfig)
+	if ref := crdConfig.Spec.ContentHashKeyRef; ref != nil && !slices.Contains(refs, *ref) {
+		refs = append(refs, *ref)
+	}
+	return refs
+}
+
+// authConfigs returns the configuration and the configurations of the secret
+// engines overriding it, ordered by engine name
+func (c *VaultConfigInternal) authConfigs() []*VaultConfigInternal {
+	configs := []*VaultConfigInternal{c}
+	for _, name := range slices.Sorted(maps.Keys(c.Engines)) {
+		configs = append(configs, c.Engines[name])
+	}
+	return configs
+}
+
+// authSecretRef returns the secret reference used by the configured auth method
+func (c *VaultConfigInternal) authSecretRef() *configv1alpha1.SecretReference {
+	switch c.AuthMethod {
+	case "token":
+		return c.TokenSecretRef
+	case "approle":
+		return c.AppRoleSecretIDRef
+	}
+	return nil
+}
+
+// resolveSecretRefs resolves the reference of the content hash key. Auth secrets are
+// resolved with withAuthSecret when a backend is created, so a missing secret only
+// fails the backend referencing it.
+func (f *BackendFactory) resolveSecretRefs(ctx context.Context, config *Config) error {
+	if config.ContentHashKeyRef == nil {
+		return nil
+	}
+
+	key, err := ResolveSecretReference(ctx, f.client, config.ContentHashKeyRef)
+	if err != nil {
+		return fmt.Errorf("failed to resolve content hash key: %w", err)
+	}
+	config.ContentHashKey = []byte(key)
+	return nil
+}
+
+// withAuthSecret returns a copy of the configuration with the secret of its auth method
+// resolved. Engines without overrides share the base configuration, so it is never
+// resolved in place. Errors wrap ErrCredentialsUnavailable.
+func withAuthSecret(ctx context.Context, c client.Client, kvConfig *VaultConfigInternal) (*VaultConfigInternal, error) {
+	resolved := *kvConfig
+	if err := resolveAuthSecret(ctx, c, &resolved); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCredentialsUnavailable, err)
+	}
+	return &resolved, nil
+}
+
+// resolveAuthSecret resolves the secret reference of the auth method of a single configuration
+func resolveAuthSecret(ctx context.Context, c client.Client, kvConfig *VaultConfigInternal) error {
+	ref := kvConfig.authSecretRef()
+	if ref == nil {
+		return nil
+	}
+
+	value, err := ResolveSecretReference(ctx, c, ref)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s auth secret: %w", kvConfig.AuthMethod, err)
+	}
+
+	switch kvConfig.AuthMethod {
+	case "token":
+		kvConfig.Token = value
+	case "approle":
+		kvConfig.AppRoleSecretID = value
+	}
+
+	return nil
+}
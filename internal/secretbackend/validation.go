@@ -0,0 +1,256 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretbackend
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template/parse"
+
+	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+)
+
+// ValidateConfig checks a SecretBackendConfig for errors that would make every
+// sync with it fail: an unsupported backend or one without its configuration,
+// invalid sync selectors, path templates that cannot be parsed or rendered and
+// secret engines that conflict with each other. All problems found are returned
+// joined.
+func ValidateConfig(crdConfig *configv1alpha1.SecretBackendConfig) error {
+	config, err := LoadConfigFromCRD(crdConfig)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	switch config.Backend {
+	case defaultBackendType:
+		if config.VaultConfig == nil {
+			errs = append(errs, errors.New("vaultConfig is required when backend is vault"))
+		}
+	case openBaoBackendType:
+		if config.OpenBaoConfig == nil {
+			errs = append(errs, errors.New("openBaoConfig is required when backend is openbao"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unsupported backend type: %s", config.Backend))
+	}
+
+	if err := validatePathTemplate(config.PathTemplate); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Engines writing with the same template to the same mount would overwrite
+	// each other's secrets
+	engineNames := make(map[string]bool)
+	enginePaths := make(map[string]string)
+	for _, engine := range secretEngines(&crdConfig.Spec) {
+		if engineNames[engine.Name] {
+			errs = append(errs, fmt.Errorf("duplicate engine name %s", engine.Name))
+			continue
+		}
+		engineNames[engine.Name] = true
+
+		if engine.SyncSelector == nil && engine.SyncLabel == "" {
+			errs = append(errs, fmt.Errorf("engine %s requires a syncSelector or syncLabel", engine.Name))
+		} else if _, err := SyncSelector(engine.SyncSelector, engine.SyncLabel); err != nil {
+			errs = append(errs, fmt.Errorf("invalid sync selector for engine %s: %w", engine.Name, err))
+		}
+
+		pathTemplate := engine.PathTemplate
+		if pathTemplate == "" {
+			pathTemplate = DefaultPathTemplate
+		}
+		if err := validatePathTemplate(pathTemplate); err != nil {
+			errs = append(errs, fmt.Errorf("engine %s: %w", engine.Name, err))
+		}
+
+		if kvConfig := config.kvConfig(); kvConfig != nil {
+			engineConfig := kvConfig.engineConfig(engine.Name)
+			key := strings.Join([]string{engineConfig.Address, engineConfig.Namespace, engine.MountPath, pathTemplate}, "\x00")
+			if other, ok := enginePaths[key]; ok {
+				errs = append(errs, fmt.Errorf("engines %s and %s write to the same mount %s with the same path template",
+					other, engine.Name, engine.MountPath))
+			} else {
+				enginePaths[key] = engine.Name
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validatePathTemplate parses a path template and renders it for sample BMCs, so
+// references to unknown variables are found before the first sync
+func validatePathTemplate(pathTemplate string) error {
+	_, err := samplePaths(pathTemplate)
+	return err
+}
+
+// ValidateDistinctPaths checks that the path templates of a SecretBackendConfig render
+// distinct paths for distinct BMCs sharing a BMCSecret. Unlike ValidateConfig, it is
+// only enforced when a config is created or updated: stored configs rendering the same
+// path keep syncing. Templates that cannot be rendered are left to ValidateConfig.
+func ValidateDistinctPaths(crdConfig *configv1alpha1.SecretBackendConfig) error {
+	pathTemplate := crdConfig.Spec.PathTemplate
+	if pathTemplate == "" {
+		pathTemplate = DefaultPathTemplate
+	}
+
+	var errs []error
+	if err := validateDistinctPaths(pathTemplate); err != nil {
+		errs = append(errs, err)
+	}
+	for _, engine := range secretEngines(&crdConfig.Spec) {
+		pathTemplate := engine.PathTemplate
+		if pathTemplate == "" {
+			pathTemplate = DefaultPathTemplate
+		}
+		if err := validateDistinctPaths(pathTemplate); err != nil {
+			errs = append(errs, fmt.Errorf("engine %s: %w", engine.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateDistinctPaths renders a path template for two sample BMCs and fails if both
+// get the same path
+func validateDistinctPaths(pathTemplate string) error {
+	paths, err := samplePaths(pathTemplate)
+	if err != nil || paths[0] != paths[1] {
+		return nil
+	}
+	return fmt.Errorf("path template renders the same path %s for distinct BMCs, it must include a variable identifying the BMC such as .Hostname or .BMCName", paths[0])
+}
+
+// samplePaths renders a path template for the sample BMCs of samplePathVariables
+func samplePaths(pathTemplate string) ([]string, error) {
+	pathBuilder, err := NewPathBuilder(pathTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, vars := range samplePathVariables(pathBuilder) {
+		path, err := pathBuilder.Build(vars)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// samplePathVariables returns the variables of two sample BMCs sharing a BMCSecret
+// and region that path templates are test-rendered with. Every label and annotation
+// the template references has a value, distinct for each BMC.
+func samplePathVariables(pathBuilder *PathBuilder) []PathVariables {
+	keys := make(map[string][]string)
+	if pathBuilder.template.Tree != nil {
+		referencedMapKeys(pathBuilder.template.Root, keys)
+	}
+
+	var samples []PathVariables
+	for i, suffix := range []string{"a", "b"} {
+		labels := make(map[string]string)
+		for _, key := range keys["Labels"] {
+			labels[key] = "label-" + suffix
+		}
+		annotations := make(map[string]string)
+		for _, key := range keys["Annotations"] {
+			annotations[key] = "annotation-" + suffix
+		}
+
+		samples = append(samples, PathVariables{
+			Region:      "us-east-1",
+			Hostname:    "bmc-sample-" + suffix + ".example.com",
+			Username:    "admin",
+			BMCName:     "bmc-sample-" + suffix,
+			SecretName:  "bmc-sample-credentials",
+			Labels:      labels,
+			Annotations: annotations,
+			Protocol:    "Redfish",
+			Port:        443,
+			MACAddress:  fmt.Sprintf("aa:bb:cc:dd:ee:%02x", i+1),
+			IP:          fmt.Sprintf("192.0.2.%d", i+1),
+		})
+	}
+	return samples
+}
+
+// referencedMapKeys collects the label and annotation keys referenced below a node of
+// a path template, as in {{.Labels.rack}} or {{index .Annotations "serial"}}, by map name
+func referencedMapKeys(node parse.Node, keys map[string][]string) {
+	addField := func(ident []string) {
+		if len(ident) == 2 && (ident[0] == "Labels" || ident[0] == "Annotations") {
+			keys[ident[0]] = append(keys[ident[0]], ident[1])
+		}
+	}
+
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			referencedMapKeys(child, keys)
+		}
+	case *parse.ActionNode:
+		referencedMapKeys(n.Pipe, keys)
+	case *parse.IfNode:
+		referencedBranchKeys(&n.BranchNode, keys)
+	case *parse.RangeNode:
+		referencedBranchKeys(&n.BranchNode, keys)
+	case *parse.WithNode:
+		referencedBranchKeys(&n.BranchNode, keys)
+	case *parse.TemplateNode:
+		referencedMapKeys(n.Pipe, keys)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			referencedMapKeys(cmd, keys)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) == 3 {
+			function, isIdentifier := n.Args[0].(*parse.IdentifierNode)
+			field, isField := n.Args[1].(*parse.FieldNode)
+			key, isString := n.Args[2].(*parse.StringNode)
+			if isIdentifier && function.Ident == "index" && isField && len(field.Ident) == 1 && isString {
+				addField([]string{field.Ident[0], key.Text})
+			}
+		}
+		for _, arg := range n.Args {
+			referencedMapKeys(arg, keys)
+		}
+	case *parse.FieldNode:
+		addField(n.Ident)
+	case *parse.VariableNode:
+		if len(n.Ident) > 0 && n.Ident[0] == "$" {
+			addField(n.Ident[1:])
+		}
+	}
+}
+
+// referencedBranchKeys collects the label and annotation keys referenced by the
+// pipeline and both branches of an if, range or with action
+func referencedBranchKeys(n *parse.BranchNode, keys map[string][]string) {
+	referencedMapKeys(n.Pipe, keys)
+	referencedMapKeys(n.List, keys)
+	referencedMapKeys(n.ElseList, keys)
+}
@@ -20,37 +20,40 @@ import (
 	"fmt"
 	"os"
 	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
 )
 
 const (
 	defaultServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 )
 
-// authenticate authenticates with Vault using the configured method
-func (v *VaultBackend) authenticate(config *Config) error {
+// authenticate authenticates with Vault using the configured method and returns
+// the auth data of the resulting token
+func (v *VaultBackend) authenticate(config *Config) (*vaultapi.SecretAuth, error) {
 	switch config.AuthMethod {
 	case "kubernetes":
 		return v.authenticateKubernetes(config)
 	case "token":
 		return v.authenticateToken(config)
 	case "approle":
-		return fmt.Errorf("approle authentication not yet implemented")
+		return v.authenticateAppRole(config)
 	default:
-		return fmt.Errorf("unsupported auth method: %s", config.AuthMethod)
+		return nil, fmt.Errorf("unsupported auth method: %s", config.AuthMethod)
 	}
 }
 
 // authenticateKubernetes authenticates using Kubernetes service account
-func (v *VaultBackend) authenticateKubernetes(config *Config) error {
+func (v *VaultBackend) authenticateKubernetes(config *Config) (*vaultapi.SecretAuth, error) {
 	start := time.Now()
 
 	// Read service account token
 	tokenBytes, err := os.ReadFile(defaultServiceAccountTokenPath)
 	if err != nil {
 		if v.metricsCollector != nil {
-			v.metricsCollector.RecordAuth("kubernetes", "vault", time.Since(start), err)
+			v.metricsCollector.RecordAuth("kubernetes", v.backendType, v.config.Namespace, time.Since(start), err)
 		}
-		return fmt.Errorf("failed to read service account token: %w", err)
+		return nil, fmt.Errorf("failed to read service account token: %w", err)
 	}
 	jwt := string(tokenBytes)
 
@@ -65,52 +68,105 @@ func (v *VaultBackend) authenticateKubernetes(config *Config) error {
 	secret, err := v.client.Logical().Write(authPath, loginData)
 	if err != nil {
 		if v.metricsCollector != nil {
-			v.metricsCollector.RecordAuth("kubernetes", "vault", time.Since(start), err)
+			v.metricsCollector.RecordAuth("kubernetes", v.backendType, v.config.Namespace, time.Since(start), err)
 		}
-		return fmt.Errorf("kubernetes auth login failed: %w", err)
+		return nil, fmt.Errorf("kubernetes auth login failed: %w", err)
 	}
 
 	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
 		err = fmt.Errorf("kubernetes auth returned no token")
 		if v.metricsCollector != nil {
-			v.metricsCollector.RecordAuth("kubernetes", "vault", time.Since(start), err)
+			v.metricsCollector.RecordAuth("kubernetes", v.backendType, v.config.Namespace, time.Since(start), err)
 		}
-		return err
+		return nil, err
 	}
 
 	// Set the token
 	v.client.SetToken(secret.Auth.ClientToken)
 
 	if v.metricsCollector != nil {
-		v.metricsCollector.RecordAuth("kubernetes", "vault", time.Since(start), nil)
+		v.metricsCollector.RecordAuth("kubernetes", v.backendType, v.config.Namespace, time.Since(start), nil)
 	}
 
-	return nil
+	return secret.Auth, nil
 }
 
 // authenticateToken authenticates using a pre-configured token
-func (v *VaultBackend) authenticateToken(config *Config) error {
+func (v *VaultBackend) authenticateToken(config *Config) (*vaultapi.SecretAuth, error) {
 	start := time.Now()
 
 	if config.Token == "" {
 		err := fmt.Errorf("token is required for token authentication")
 		if v.metricsCollector != nil {
-			v.metricsCollector.RecordAuth("token", "vault", time.Since(start), err)
+			v.metricsCollector.RecordAuth("token", v.backendType, v.config.Namespace, time.Since(start), err)
 		}
-		return err
+		return nil, err
 	}
 
 	v.client.SetToken(config.Token)
 
 	// Verify token is valid
-	_, err := v.client.Auth().Token().LookupSelf()
+	secret, err := v.client.Auth().Token().LookupSelf()
 	if v.metricsCollector != nil {
-		v.metricsCollector.RecordAuth("token", "vault", time.Since(start), err)
+		v.metricsCollector.RecordAuth("token", v.backendType, v.config.Namespace, time.Since(start), err)
 	}
 
 	if err != nil {
-		return fmt.Errorf("token validation failed: %w", err)
+		return nil, fmt.Errorf("token validation failed: %w", err)
+	}
+
+	// Static tokens are not logged in, so build the auth data from the lookup
+	ttl, _ := secret.TokenTTL()
+	renewable, _ := secret.TokenIsRenewable()
+	return &vaultapi.SecretAuth{
+		ClientToken:   config.Token,
+		LeaseDuration: int(ttl.Seconds()),
+		Renewable:     renewable,
+	}, nil
+}
+
+// authenticateAppRole authenticates using an AppRole role ID and secret ID
+func (v *VaultBackend) authenticateAppRole(config *Config) (*vaultapi.SecretAuth, error) {
+	start := time.Now()
+
+	if config.AppRoleRoleID == "" || config.AppRoleSecretID == "" {
+		err := fmt.Errorf("role ID and secret ID are required for approle authentication")
+		if v.metricsCollector != nil {
+			v.metricsCollector.RecordAuth("approle", v.backendType, v.config.Namespace, time.Since(start), err)
+		}
+		return nil, err
+	}
+
+	// Prepare login data
+	loginData := map[string]any{
+		"role_id":   config.AppRoleRoleID,
+		"secret_id": config.AppRoleSecretID,
+	}
+
+	// Login to Vault
+	authPath := fmt.Sprintf("auth/%s/login", config.AppRolePath)
+	secret, err := v.client.Logical().Write(authPath, loginData)
+	if err != nil {
+		if v.metricsCollector != nil {
+			v.metricsCollector.RecordAuth("approle", v.backendType, v.config.Namespace, time.Since(start), err)
+		}
+		return nil, fmt.Errorf("approle auth login failed: %w", err)
+	}
+
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		err = fmt.Errorf("approle auth returned no token")
+		if v.metricsCollector != nil {
+			v.metricsCollector.RecordAuth("approle", v.backendType, v.config.Namespace, time.Since(start), err)
+		}
+		return nil, err
+	}
+
+	// Set the token
+	v.client.SetToken(secret.Auth.ClientToken)
+
+	if v.metricsCollector != nil {
+		v.metricsCollector.RecordAuth("approle", v.backendType, v.config.Namespace, time.Since(start), nil)
 	}
 
-	return nil
+	return secret.Auth, nil
 }
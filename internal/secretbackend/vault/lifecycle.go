@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+const (
+	// reloginRetryInterval is the delay between failed re-login attempts
+	// after the token expired
+	reloginRetryInterval = 10 * time.Second
+
+	// revokeTimeout bounds revoking the token when the backend is closed
+	revokeTimeout = 10 * time.Second
+)
+
+// login authenticates with the configured method and starts watching the
+// lifetime of the new token. Must be called with authMu held.
+func (v *VaultBackend) login() error {
+	auth, err := v.authenticate(v.config)
+	if err != nil {
+		return err
+	}
+
+	v.recordTokenTTL(auth.LeaseDuration)
+	return v.watchToken(auth)
+}
+
+// watchToken replaces the running lifetime watcher with one for the given
+// token. Tokens without a TTL never expire and are not watched.
+// Must be called with authMu held.
+func (v *VaultBackend) watchToken(auth *vaultapi.SecretAuth) error {
+	if v.watcher != nil {
+		v.watcher.Stop()
+		v.watcher = nil
+	}
+
+	if auth.LeaseDuration <= 0 {
+		return nil
+	}
+
+	watcher, err := v.client.NewLifetimeWatcher(&vaultapi.LifetimeWatcherInput{
+		Secret: &vaultapi.Secret{Auth: auth},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create token lifetime watcher: %w", err)
+	}
+
+	v.watcher = watcher
+	go watcher.Start()
+	go v.runWatcher(watcher)
+
+	return nil
+}
+
+// runWatcher records renewals and logs in again once the token can no longer
+// be renewed
+func (v *VaultBackend) runWatcher(watcher *vaultapi.LifetimeWatcher) {
+	for {
+		select {
+		case <-v.stopCh:
+			return
+		case renewal := <-watcher.RenewCh():
+			v.recordTokenRenewal(nil)
+			if renewal.Secret != nil && renewal.Secret.Auth != nil {
+				v.recordTokenTTL(renewal.Secret.Auth.LeaseDuration)
+			}
+		case err := <-watcher.DoneCh():
+			// DoneCh yields nil when a non-renewable token reached its TTL
+			// and an error when the renewal itself failed
+			if err != nil {
+				v.recordTokenRenewal(err)
+			}
+			v.reloginUntilStopped(watcher)
+			return
+		}
+	}
+}
+
+// reloginUntilStopped logs in again until it succeeds or the backend is closed
+func (v *VaultBackend) reloginUntilStopped(expired *vaultapi.LifetimeWatcher) {
+	for {
+		v.authMu.Lock()
+		if v.closed || v.watcher != expired {
+			// Closed, or another caller already replaced the token
+			v.authMu.Unlock()
+			return
+		}
+		err := v.login()
+		v.authMu.Unlock()
+		if err == nil {
+			return
+		}
+
+		select {
+		case <-v.stopCh:
+			return
+		case <-time.After(reloginRetryInterval):
+		}
+	}
+}
+
+// withReauth runs op and, if Vault rejects the token, logs in again and
+// retries op once
+func (v *VaultBackend) withReauth(op func() error) error {
+	token := v.client.Token()
+
+	err := op()
+	if !isPermissionDenied(err) {
+		return err
+	}
+
+	if reauthErr := v.reauthenticate(token); reauthErr != nil {
+		return fmt.Errorf("%w (re-authentication failed: %v)", err, reauthErr)
+	}
+
+	return op()
+}
+
+// reauthenticate logs in again unless the rejected token was already replaced
+// by a concurrent caller
+func (v *VaultBackend) reauthenticate(rejectedToken string) error {
+	v.authMu.Lock()
+	defer v.authMu.Unlock()
+
+	if v.closed {
+		return fmt.Errorf("backend is closed")
+	}
+	if v.client.Token() != rejectedToken {
+		return nil
+	}
+
+	return v.login()
+}
+
+// stopWatcher stops the token lifetime watcher and any pending re-login. It reports
+// whether the backend was still open.
+func (v *VaultBackend) stopWatcher() bool {
+	v.authMu.Lock()
+	defer v.authMu.Unlock()
+
+	if v.closed {
+		return false
+	}
+	v.closed = true
+	close(v.stopCh)
+
+	if v.watcher != nil {
+		v.watcher.Stop()
+		v.watcher = nil
+	}
+	return true
+}
+
+// isPermissionDenied reports whether Vault rejected the request with 403
+func isPermissionDenied(err error) bool {
+	var respErr *vaultapi.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden
+}
+
+// isNotFound reports whether Vault answered the request with 404
+func isNotFound(err error) bool {
+	var respErr *vaultapi.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
+
+// isCASMismatch reports whether Vault rejected a KV v2 write because the
+// check-and-set version did not match the current version of the secret
+func isCASMismatch(err error) bool {
+	var respErr *vaultapi.ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	return slices.ContainsFunc(respErr.Errors, func(message string) bool {
+		return strings.Contains(message, "check-and-set")
+	})
+}
+
+func (v *VaultBackend) recordTokenTTL(leaseDuration int) {
+	if v.metricsCollector != nil {
+		v.metricsCollector.RecordTokenTTL(v.backendType, v.config.ConfigName, v.config.EngineName, v.config.Namespace,
+			time.Duration(leaseDuration)*time.Second)
+	}
+}
+
+func (v *VaultBackend) recordTokenRenewal(err error) {
+	if v.metricsCollector != nil {
+		v.metricsCollector.RecordTokenRenewal(v.backendType, v.config.ConfigName, v.config.EngineName, v.config.Namespace, err)
+	}
+}
@@ -20,41 +20,95 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
-	"strings"
-	"time"
+	"sync"
 
 	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
+)
+
+const (
+	defaultBackendType = "vault"
 )
 
+// metadataDataKey is the reserved data key holding the custom metadata of a
+// secret on KV v1 mounts, which have no metadata of their own
+const metadataDataKey = "_custom_metadata"
+
+func init() {
+	secretbackend.RegisterKVBackend(defaultBackendType, func(
+		config *secretbackend.VaultConfigInternal,
+		opts secretbackend.KVBackendOptions,
+		metricsCollector secretbackend.MetricsCollector,
+	) (secretbackend.Backend, error) {
+		backend, err := NewVaultBackend(&Config{
+			Address:            config.Address,
+			Namespace:          config.Namespace,
+			AuthMethod:         config.AuthMethod,
+			KubernetesAuthRole: config.KubernetesAuthRole,
+			KubernetesAuthPath: config.KubernetesAuthPath,
+			Token:              config.Token,
+			AppRoleRoleID:      config.AppRoleRoleID,
+			AppRoleSecretID:    config.AppRoleSecretID,
+			AppRolePath:        config.AppRolePath,
+			MountPath:          opts.MountPath,
+			SkipVerify:         config.SkipVerify,
+			CACert:             config.CACert,
+			ConfigName:         opts.ConfigName,
+			EngineName:         opts.EngineName,
+		}, metricsCollector)
+		if err != nil {
+			return nil, err
+		}
+		return backend, nil
+	})
+}
+
 // Config holds Vault configuration
 type Config struct {
 	Address            string
+	Namespace          string
 	AuthMethod         string
 	KubernetesAuthRole string
 	KubernetesAuthPath string
 	Token              string
+	AppRoleRoleID      string
+	AppRoleSecretID    string
+	AppRolePath        string
 	MountPath          string
 	SkipVerify         bool
 	CACert             string
+
+	// BackendType is the backend name reported in metrics (defaults to "vault")
+	BackendType string
+
+	// ConfigName and EngineName identify the backend in the token metrics;
+	// EngineName is empty for the base backend
+	ConfigName string
+	EngineName string
 }
 
 // VaultBackend implements the Backend interface for HashiCorp Vault
 type VaultBackend struct {
 	client           *vaultapi.Client
+	config           *Config
 	mountPath        string
 	isKVv2           bool
-	metricsCollector MetricsCollector
-}
-
-// MetricsCollector defines the interface for recording metrics
-type MetricsCollector interface {
-	RecordAuth(method, backendType string, duration time.Duration, err error)
+	backendType      string
+	metricsCollector secretbackend.MetricsCollector
+
+	// authMu guards re-authentication and the token lifetime watcher
+	authMu  sync.Mutex
+	watcher *vaultapi.LifetimeWatcher
+	stopCh  chan struct{}
+	closed  bool
 }
 
 // NewVaultBackend creates a new Vault backend
-func NewVaultBackend(config *Config, metricsCollector MetricsCollector) (*VaultBackend, error) {
+func NewVaultBackend(config *Config, metricsCollector secretbackend.MetricsCollector) (*VaultBackend, error) {
 	// Create Vault client config
 	vaultConfig := vaultapi.DefaultConfig()
 	vaultConfig.Address = config.Address
@@ -82,87 +136,168 @@ func NewVaultBackend(config *Config, metricsCollector MetricsCollector) (*VaultB
 		return nil, fmt.Errorf("failed to create vault client: %w", err)
 	}
 
+	// Scope auth and KV requests to the namespace
+	if config.Namespace != "" {
+		client.SetNamespace(config.Namespace)
+	}
+
+	backendType := config.BackendType
+	if backendType == "" {
+		backendType = defaultBackendType
+	}
+
 	backend := &VaultBackend{
 		client:           client,
+		config:           config,
 		mountPath:        config.MountPath,
 		isKVv2:           true, // Default to KV v2
+		backendType:      backendType,
 		metricsCollector: metricsCollector,
+		stopCh:           make(chan struct{}),
 	}
 
-	// Authenticate
-	if err := backend.authenticate(config); err != nil {
-		return nil, fmt.Errorf("failed to authenticate with vault: %w", err)
+	// Authenticate and start renewing the token
+	backend.authMu.Lock()
+	err = backend.login()
+	backend.authMu.Unlock()
+	if err != nil {
+		backend.stopWatcher()
+		return nil, fmt.Errorf("%w with vault: %w", secretbackend.ErrAuthentication, err)
 	}
 
 	// Detect KV version
 	if err := backend.detectKVVersion(); err != nil {
-		return nil, fmt.Errorf("failed to detect KV version: %w", err)
+		_ = backend.Close()
+		return nil, fmt.Errorf("%w: %w", secretbackend.ErrMountDetection, err)
 	}
 
 	return backend, nil
 }
 
-// WriteSecret writes a secret to Vault
-func (v *VaultBackend) WriteSecret(ctx context.Context, path string, data map[string]any) error {
+// WriteSecret writes a secret to Vault together with its custom metadata and
+// returns the version it created
+func (v *VaultBackend) WriteSecret(ctx context.Context, path string, data map[string]any, opts secretbackend.WriteOptions) (secretbackend.SecretVersion, error) {
 	fullPath := v.buildPath(path)
 
-	var err error
-	if v.isKVv2 {
+	var written secretbackend.SecretVersion
+	err := v.withReauth(func() error {
+		if !v.isKVv2 {
+			// KV v1 uses direct path and keeps the metadata with the data
+			_, err := v.client.Logical().WriteWithContext(ctx, fullPath, withMetadata(data, opts.Metadata))
+			return err
+		}
+
+		var putOpts []vaultapi.KVOption
+		if opts.CAS != nil {
+			putOpts = append(putOpts, vaultapi.WithCheckAndSet(*opts.CAS))
+		}
 		// KV v2 requires data wrapped in "data" key
-		_, err = v.client.KVv2(v.mountPath).Put(ctx, path, data)
-	} else {
-		// KV v1 uses direct path
-		_, err = v.client.Logical().WriteWithContext(ctx, fullPath, data)
-	}
+		kvSecret, err := v.client.KVv2(v.mountPath).Put(ctx, path, data, putOpts...)
+		if isCASMismatch(err) {
+			return fmt.Errorf("%w: %w", secretbackend.ErrCASMismatch, err)
+		}
+		if err != nil {
+			return err
+		}
+		written = secretVersion(kvSecret)
+
+		// The metadata is only written once the data is, so a write losing the
+		// check-and-set leaves the marker of the concurrent writer in place
+		if len(opts.Metadata) > 0 {
+			return v.writeMetadata(ctx, path, opts.Metadata)
+		}
+		return nil
+	})
 
 	if err != nil {
-		return fmt.Errorf("failed to write secret to vault at %s: %w", fullPath, err)
+		return secretbackend.SecretVersion{}, fmt.Errorf("failed to write secret to vault at %s: %w", fullPath, err)
 	}
 
-	return nil
+	return written, nil
 }
 
-// ReadSecret reads a secret from Vault
-func (v *VaultBackend) ReadSecret(ctx context.Context, path string) (map[string]any, error) {
-	fullPath := v.buildPath(path)
+// writeMetadata sets the given custom metadata keys of a KV v2 secret, leaving
+// other custom metadata and settings untouched
+func (v *VaultBackend) writeMetadata(ctx context.Context, path string, metadata map[string]string) error {
+	customMetadata := make(map[string]any, len(metadata))
+	for key, value := range metadata {
+		customMetadata[key] = value
+	}
 
-	var secret *vaultapi.KVSecret
-	var logicalSecret *vaultapi.Secret
-	var err error
+	err := v.client.KVv2(v.mountPath).PatchMetadata(ctx, path, vaultapi.KVMetadataPatchInput{
+		CustomMetadata: customMetadata,
+	})
+	if !isNotFound(err) {
+		return err
+	}
 
-	if v.isKVv2 {
-		secret, err = v.client.KVv2(v.mountPath).Get(ctx, path)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read secret from vault at %s: %w", fullPath, err)
-		}
-		if secret == nil || secret.Data == nil {
-			return nil, fmt.Errorf("secret not found at %s", fullPath)
+	// Patching requires existing metadata, new secrets get it created
+	return v.client.KVv2(v.mountPath).PutMetadata(ctx, path, vaultapi.KVMetadataPutInput{
+		CustomMetadata: customMetadata,
+	})
+}
+
+// ReadSecret reads a secret and its custom metadata from Vault
+func (v *VaultBackend) ReadSecret(ctx context.Context, path string) (*secretbackend.Secret, error) {
+	fullPath := v.buildPath(path)
+
+	var secret *secretbackend.Secret
+	err := v.withReauth(func() error {
+		if v.isKVv2 {
+			kvSecret, err := v.client.KVv2(v.mountPath).Get(ctx, path)
+			if errors.Is(err, vaultapi.ErrSecretNotFound) {
+				// Missing or deleted; reported as secretbackend.ErrSecretNotFound below
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if kvSecret != nil && kvSecret.Data != nil {
+				secret = &secretbackend.Secret{
+					Data:          kvSecret.Data,
+					Metadata:      stringMap(kvSecret.CustomMetadata),
+					SecretVersion: secretVersion(kvSecret),
+				}
+			}
+			return nil
 		}
-		return secret.Data, nil
-	} else {
-		logicalSecret, err = v.client.Logical().ReadWithContext(ctx, fullPath)
+
+		logicalSecret, err := v.client.Logical().ReadWithContext(ctx, fullPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read secret from vault at %s: %w", fullPath, err)
+			return err
 		}
-		if logicalSecret == nil || logicalSecret.Data == nil {
-			return nil, fmt.Errorf("secret not found at %s", fullPath)
+		if logicalSecret != nil && logicalSecret.Data != nil {
+			secret = splitMetadata(logicalSecret.Data)
 		}
-		return logicalSecret.Data, nil
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret from vault at %s: %w", fullPath, err)
 	}
+	if secret == nil {
+		return nil, fmt.Errorf("%w at %s", secretbackend.ErrSecretNotFound, fullPath)
+	}
+	return secret, nil
 }
 
 // DeleteSecret deletes a secret from Vault
-func (v *VaultBackend) DeleteSecret(ctx context.Context, path string) error {
+func (v *VaultBackend) DeleteSecret(ctx context.Context, path string, opts secretbackend.DeleteOptions) error {
 	fullPath := v.buildPath(path)
 
-	var err error
-	if v.isKVv2 {
-		// KV v2 uses metadata delete to permanently remove all versions
-		err = v.client.KVv2(v.mountPath).DeleteMetadata(ctx, path)
-	} else {
+	err := v.withReauth(func() error {
+		if v.isKVv2 && opts.SoftDelete {
+			// KV v2 data delete marks the latest version as deleted
+			return v.client.KVv2(v.mountPath).Delete(ctx, path)
+		}
+		if v.isKVv2 {
+			// KV v2 uses metadata delete to permanently remove all versions
+			return v.client.KVv2(v.mountPath).DeleteMetadata(ctx, path)
+		}
 		// KV v1 uses logical delete
-		_, err = v.client.Logical().DeleteWithContext(ctx, fullPath)
-	}
+		_, err := v.client.Logical().DeleteWithContext(ctx, fullPath)
+		return err
+	})
 
 	if err != nil {
 		return fmt.Errorf("failed to delete secret from vault at %s: %w", fullPath, err)
@@ -175,7 +310,7 @@ func (v *VaultBackend) DeleteSecret(ctx context.Context, path string) error {
 func (v *VaultBackend) SecretExists(ctx context.Context, path string) (bool, error) {
 	_, err := v.ReadSecret(ctx, path)
 	if err != nil {
-		if strings.Contains(err.Error(), "secret not found") {
+		if errors.Is(err, secretbackend.ErrSecretNotFound) {
 			return false, nil
 		}
 		return false, err
@@ -183,12 +318,94 @@ func (v *VaultBackend) SecretExists(ctx context.Context, path string) (bool, err
 	return true, nil
 }
 
-// Close closes the Vault client
+// CheckHealth verifies that Vault is reachable and accepts the token by looking
+// it up, logging in again if the token was revoked. A rejected login is
+// returned wrapped in secretbackend.ErrAuthentication.
+func (v *VaultBackend) CheckHealth(ctx context.Context) error {
+	err := v.withReauth(func() error {
+		_, err := v.client.Auth().Token().LookupSelfWithContext(ctx)
+		return err
+	})
+	if err == nil {
+		return nil
+	}
+	if isPermissionDenied(err) {
+		return fmt.Errorf("%w with vault: %w", secretbackend.ErrAuthentication, err)
+	}
+	return fmt.Errorf("failed to reach vault: %w", err)
+}
+
+// Close stops the token lifetime watcher and revokes the token the backend logged in
+// with, so that closed backends do not leave tokens behind until they expire. Tokens of
+// the token auth method are given to the backend and not revoked.
 func (v *VaultBackend) Close() error {
-	// Vault client doesn't require explicit cleanup
+	if !v.stopWatcher() || v.config.AuthMethod == "token" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), revokeTimeout)
+	defer cancel()
+	// A rejected token is no longer valid and needs no revoking
+	if err := v.client.Auth().Token().RevokeSelfWithContext(ctx, ""); err != nil && !isPermissionDenied(err) {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
 	return nil
 }
 
+// secretVersion returns the version metadata of a KV v2 secret
+func secretVersion(kvSecret *vaultapi.KVSecret) secretbackend.SecretVersion {
+	if kvSecret == nil || kvSecret.VersionMetadata == nil {
+		return secretbackend.SecretVersion{}
+	}
+	return secretbackend.SecretVersion{
+		Version:     kvSecret.VersionMetadata.Version,
+		CreatedTime: kvSecret.VersionMetadata.CreatedTime,
+	}
+}
+
+// withMetadata returns the KV v1 data with the metadata stored under the reserved key
+func withMetadata(data map[string]any, metadata map[string]string) map[string]any {
+	if len(metadata) == 0 {
+		return data
+	}
+
+	stored := make(map[string]any, len(data)+1)
+	for key, value := range data {
+		stored[key] = value
+	}
+	stored[metadataDataKey] = metadata
+	return stored
+}
+
+// splitMetadata separates the metadata stored under the reserved key from KV v1 data
+func splitMetadata(stored map[string]any) *secretbackend.Secret {
+	secret := &secretbackend.Secret{Data: make(map[string]any, len(stored))}
+	for key, value := range stored {
+		if key == metadataDataKey {
+			metadata, _ := value.(map[string]any)
+			secret.Metadata = stringMap(metadata)
+			continue
+		}
+		secret.Data[key] = value
+	}
+	return secret
+}
+
+// stringMap converts decoded JSON metadata into a string map, dropping non-string values
+func stringMap(values map[string]any) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		if s, ok := value.(string); ok {
+			result[key] = s
+		}
+	}
+	return result
+}
+
 // buildPath constructs the full Vault path
 func (v *VaultBackend) buildPath(path string) string {
 	if v.isKVv2 {
@@ -0,0 +1,485 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend/vaulttest"
+)
+
+func TestVault(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Vault Backend Suite")
+}
+
+type authRecord struct {
+	method      string
+	backendType string
+	namespace   string
+	err         error
+}
+
+type tokenRecord struct {
+	configName string
+	engine     string
+	namespace  string
+	ttl        time.Duration
+}
+
+type fakeMetricsCollector struct {
+	mu       sync.Mutex
+	auths    []authRecord
+	ttls     []tokenRecord
+	renewals int
+}
+
+func (f *fakeMetricsCollector) RecordBackendOperation(_, _, _ string, _ time.Duration, _ error) {}
+
+func (f *fakeMetricsCollector) RecordBackendOperationWithEngine(_, _, _, _ string, _ time.Duration, _ error) {
+}
+
+func (f *fakeMetricsCollector) RecordAuth(method, backendType, namespace string, _ time.Duration, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auths = append(f.auths, authRecord{method: method, backendType: backendType, namespace: namespace, err: err})
+}
+
+func (f *fakeMetricsCollector) RecordTokenTTL(_, configName, engine, namespace string, ttl time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ttls = append(f.ttls, tokenRecord{configName: configName, engine: engine, namespace: namespace, ttl: ttl})
+}
+
+func (f *fakeMetricsCollector) RecordTokenRenewal(_, _, _, _ string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		f.renewals++
+	}
+}
+
+func (f *fakeMetricsCollector) successfulRenewals() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.renewals
+}
+
+var _ = Describe("VaultBackend", func() {
+	var (
+		ctx     context.Context
+		server  *vaulttest.Server
+		metrics *fakeMetricsCollector
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = vaulttest.NewServer()
+		server.AddMount("secret", 2)
+		server.AddAppRole("bmc-operator", "secret-id")
+		metrics = &fakeMetricsCollector{}
+		DeferCleanup(server.Close)
+	})
+
+	newAppRoleConfig := func(roleID, secretID string) *Config {
+		return &Config{
+			Address:         server.URL,
+			AuthMethod:      "approle",
+			AppRoleRoleID:   roleID,
+			AppRoleSecretID: secretID,
+			AppRolePath:     "approle",
+			MountPath:       "secret",
+			CACert:          server.CACert(),
+		}
+	}
+
+	Context("When using AppRole authentication", func() {
+		It("Should log in and use the returned token", func() {
+			backend, err := NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+			Expect(server.Requests).To(ContainElement("PUT /v1/auth/approle/login"))
+
+			data := map[string]any{"username": "admin", "password": "secret123"}
+			Expect(backend.WriteSecret(ctx, "bmc/us-east-1/bmc1/admin", data, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+
+			Expect(metrics.auths).To(ConsistOf(authRecord{method: "approle", backendType: "vault"}))
+		})
+
+		It("Should fail with an invalid secret ID", func() {
+			_, err := NewVaultBackend(newAppRoleConfig("bmc-operator", "wrong"), metrics)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("approle auth login failed"))
+
+			Expect(metrics.auths).To(HaveLen(1))
+			Expect(metrics.auths[0].method).To(Equal("approle"))
+			Expect(metrics.auths[0].err).To(HaveOccurred())
+		})
+
+		It("Should require the role ID and secret ID", func() {
+			_, err := NewVaultBackend(newAppRoleConfig("bmc-operator", ""), metrics)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("role ID and secret ID are required"))
+		})
+	})
+
+	Context("When a namespace is configured", func() {
+		It("Should send it with the login and KV requests", func() {
+			config := newAppRoleConfig("bmc-operator", "secret-id")
+			config.Namespace = "admin/team-a"
+			backend, err := NewVaultBackend(config, metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			data := map[string]any{"username": "admin", "password": "secret123"}
+			Expect(backend.WriteSecret(ctx, "bmc/us-east-1/bmc1/admin", data, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+
+			Expect(server.Requests).To(ContainElements("PUT /v1/auth/approle/login", "GET /v1/sys/mounts"))
+			Expect(server.Namespaces).To(HaveEach("admin/team-a"))
+			Expect(metrics.auths).To(ConsistOf(authRecord{method: "approle", backendType: "vault", namespace: "admin/team-a"}))
+		})
+
+		It("Should not send a namespace by default", func() {
+			backend, err := NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			Expect(server.Namespaces).NotTo(BeEmpty())
+			Expect(server.Namespaces).To(HaveEach(BeEmpty()))
+		})
+	})
+
+	Context("When a secret does not exist", func() {
+		It("Should return secretbackend.ErrSecretNotFound for KV v1 and KV v2 mounts", func() {
+			server.AddMount("kv", 1)
+
+			for _, mount := range []string{"secret", "kv"} {
+				config := newAppRoleConfig("bmc-operator", "secret-id")
+				config.MountPath = mount
+				backend, err := NewVaultBackend(config, metrics)
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(backend.Close)
+
+				_, err = backend.ReadSecret(ctx, "bmc/us-east-1/missing/admin")
+				Expect(err).To(MatchError(secretbackend.ErrSecretNotFound))
+
+				exists, err := backend.SecretExists(ctx, "bmc/us-east-1/missing/admin")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeFalse())
+			}
+		})
+	})
+
+	Context("When writing custom metadata", func() {
+		metadata := map[string]string{"managed-by": "bmc-secret-operator", "bmc-secret": "shared"}
+
+		It("Should store it as KV v2 custom metadata", func() {
+			backend, err := NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			data := map[string]any{"username": "admin", "password": "secret123"}
+			Expect(backend.WriteSecret(ctx, "bmc/us-east-1/bmc1/admin", data, secretbackend.WriteOptions{Metadata: metadata})).Error().NotTo(HaveOccurred())
+
+			stored, ok := server.Secret("secret", "bmc/us-east-1/bmc1/admin")
+			Expect(ok).To(BeTrue())
+			Expect(stored).To(Equal(data))
+			customMetadata, ok := server.CustomMetadata("secret", "bmc/us-east-1/bmc1/admin")
+			Expect(ok).To(BeTrue())
+			Expect(customMetadata).To(HaveKeyWithValue("bmc-secret", "shared"))
+
+			read, err := backend.ReadSecret(ctx, "bmc/us-east-1/bmc1/admin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Data).To(Equal(data))
+			Expect(read.Metadata).To(Equal(metadata))
+		})
+
+		It("Should keep custom metadata set by others when updating it", func() {
+			backend, err := NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			data := map[string]any{"username": "admin", "password": "secret123"}
+			Expect(backend.WriteSecret(ctx, "bmc/us-east-1/bmc1/admin", data, secretbackend.WriteOptions{Metadata: map[string]string{"team": "a"}})).Error().NotTo(HaveOccurred())
+			Expect(backend.WriteSecret(ctx, "bmc/us-east-1/bmc1/admin", data, secretbackend.WriteOptions{Metadata: metadata})).Error().NotTo(HaveOccurred())
+			Expect(server.Count("PATCH /v1/secret/metadata/bmc/us-east-1/bmc1/admin")).To(Equal(2))
+
+			read, err := backend.ReadSecret(ctx, "bmc/us-east-1/bmc1/admin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Metadata).To(HaveKeyWithValue("team", "a"))
+			Expect(read.Metadata).To(HaveKeyWithValue("managed-by", "bmc-secret-operator"))
+		})
+
+		It("Should store it under a reserved data key on KV v1", func() {
+			server.AddMount("kv", 1)
+			config := newAppRoleConfig("bmc-operator", "secret-id")
+			config.MountPath = "kv"
+			backend, err := NewVaultBackend(config, metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			data := map[string]any{"username": "admin", "password": "secret123"}
+			Expect(backend.WriteSecret(ctx, "bmc/us-east-1/bmc1/admin", data, secretbackend.WriteOptions{Metadata: metadata})).Error().NotTo(HaveOccurred())
+
+			stored, ok := server.Secret("kv", "bmc/us-east-1/bmc1/admin")
+			Expect(ok).To(BeTrue())
+			Expect(stored).To(HaveKey(metadataDataKey))
+
+			read, err := backend.ReadSecret(ctx, "bmc/us-east-1/bmc1/admin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Data).To(Equal(data))
+			Expect(read.Metadata).To(Equal(metadata))
+		})
+	})
+
+	Context("When writing with check-and-set", func() {
+		const casPath = "bmc/us-east-1/bmc1/admin"
+
+		var backend *VaultBackend
+
+		BeforeEach(func() {
+			var err error
+			backend, err = NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+		})
+
+		It("Should write when the version read matches", func() {
+			created := 0
+			Expect(backend.WriteSecret(ctx, casPath, map[string]any{"password": "one"}, secretbackend.WriteOptions{CAS: &created})).Error().NotTo(HaveOccurred())
+
+			read, err := backend.ReadSecret(ctx, casPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Version).To(Equal(1))
+
+			Expect(backend.WriteSecret(ctx, casPath, map[string]any{"password": "two"}, secretbackend.WriteOptions{CAS: &read.Version})).Error().NotTo(HaveOccurred())
+
+			read, err = backend.ReadSecret(ctx, casPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Version).To(Equal(2))
+			Expect(read.Data).To(Equal(map[string]any{"password": "two"}))
+		})
+
+		It("Should return the version created by the write", func() {
+			written, err := backend.WriteSecret(ctx, casPath, map[string]any{"password": "one"}, secretbackend.WriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(written.Version).To(Equal(1))
+			Expect(written.CreatedTime).To(BeTemporally("~", time.Now(), time.Minute))
+
+			written, err = backend.WriteSecret(ctx, casPath, map[string]any{"password": "two"}, secretbackend.WriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(written.Version).To(Equal(2))
+
+			read, err := backend.ReadSecret(ctx, casPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Version).To(Equal(2))
+			Expect(read.CreatedTime).To(BeTemporally("==", written.CreatedTime))
+		})
+
+		It("Should fail with secretbackend.ErrCASMismatch when the secret changed since it was read", func() {
+			Expect(backend.WriteSecret(ctx, casPath, map[string]any{"password": "one"}, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+			read, err := backend.ReadSecret(ctx, casPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			server.SetSecret("secret", casPath, map[string]any{"password": "concurrent"})
+
+			_, err = backend.WriteSecret(ctx, casPath, map[string]any{"password": "two"}, secretbackend.WriteOptions{CAS: &read.Version})
+			Expect(err).To(MatchError(secretbackend.ErrCASMismatch))
+			stored, _ := server.Secret("secret", casPath)
+			Expect(stored).To(Equal(map[string]any{"password": "concurrent"}))
+		})
+
+		It("Should leave the metadata of the concurrent writer when the check-and-set fails", func() {
+			Expect(backend.WriteSecret(ctx, casPath, map[string]any{"password": "one"}, secretbackend.WriteOptions{
+				Metadata: map[string]string{"owner": "first"},
+			})).Error().NotTo(HaveOccurred())
+			read, err := backend.ReadSecret(ctx, casPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			server.SetSecret("secret", casPath, map[string]any{"password": "concurrent"})
+			server.SetCustomMetadata("secret", casPath, map[string]any{"owner": "concurrent"})
+
+			_, err = backend.WriteSecret(ctx, casPath, map[string]any{"password": "two"}, secretbackend.WriteOptions{
+				CAS:      &read.Version,
+				Metadata: map[string]string{"owner": "first"},
+			})
+			Expect(err).To(MatchError(secretbackend.ErrCASMismatch))
+			metadata, _ := server.CustomMetadata("secret", casPath)
+			Expect(metadata).To(Equal(map[string]any{"owner": "concurrent"}))
+		})
+
+		It("Should not create a secret with version 0 when it already exists", func() {
+			server.SetSecret("secret", casPath, map[string]any{"password": "concurrent"})
+
+			created := 0
+			_, err := backend.WriteSecret(ctx, casPath, map[string]any{"password": "one"}, secretbackend.WriteOptions{CAS: &created})
+			Expect(err).To(MatchError(secretbackend.ErrCASMismatch))
+		})
+	})
+
+	Context("When deleting secrets", func() {
+		const deletePath = "bmc/us-east-1/bmc1/admin"
+
+		var backend *VaultBackend
+
+		BeforeEach(func() {
+			var err error
+			backend, err = NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			Expect(backend.WriteSecret(ctx, deletePath, map[string]any{"password": "one"}, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+			Expect(backend.WriteSecret(ctx, deletePath, map[string]any{"password": "two"},
+				secretbackend.WriteOptions{Metadata: map[string]string{"team": "a"}})).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should destroy all versions and the metadata by default", func() {
+			Expect(backend.DeleteSecret(ctx, deletePath, secretbackend.DeleteOptions{})).To(Succeed())
+
+			Expect(server.Requests).To(ContainElement("DELETE /v1/secret/metadata/" + deletePath))
+			Expect(server.Versions("secret", deletePath)).To(BeZero())
+			_, ok := server.CustomMetadata("secret", deletePath)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("Should only delete the latest version when soft deleting", func() {
+			Expect(backend.DeleteSecret(ctx, deletePath, secretbackend.DeleteOptions{SoftDelete: true})).To(Succeed())
+
+			Expect(server.Requests).To(ContainElement("DELETE /v1/secret/data/" + deletePath))
+			Expect(server.Requests).NotTo(ContainElement("DELETE /v1/secret/metadata/" + deletePath))
+			Expect(server.Versions("secret", deletePath)).To(Equal(2))
+			customMetadata, ok := server.CustomMetadata("secret", deletePath)
+			Expect(ok).To(BeTrue())
+			Expect(customMetadata).To(HaveKeyWithValue("team", "a"))
+
+			_, err := backend.ReadSecret(ctx, deletePath)
+			Expect(err).To(MatchError(secretbackend.ErrSecretNotFound))
+		})
+
+		It("Should write a soft deleted secret again without check-and-set", func() {
+			Expect(backend.DeleteSecret(ctx, deletePath, secretbackend.DeleteOptions{SoftDelete: true})).To(Succeed())
+
+			written, err := backend.WriteSecret(ctx, deletePath, map[string]any{"password": "three"}, secretbackend.WriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(written.Version).To(Equal(3))
+		})
+	})
+
+	Context("When managing the token lifecycle", func() {
+		It("Should record the token TTL after login by config and engine", func() {
+			config := newAppRoleConfig("bmc-operator", "secret-id")
+			config.ConfigName = "region-a"
+			config.EngineName = "team-a"
+			backend, err := NewVaultBackend(config, metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			Expect(metrics.ttls).To(ConsistOf(tokenRecord{configName: "region-a", engine: "team-a", ttl: time.Hour}))
+		})
+
+		It("Should renew renewable tokens before they expire", func() {
+			server.SetTokenTTL(2)
+
+			backend, err := NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			Eventually(func() int {
+				return server.Count("PUT /v1/auth/token/renew-self")
+			}, 5*time.Second, 100*time.Millisecond).Should(BeNumerically(">=", 1))
+			Eventually(metrics.successfulRenewals, 5*time.Second, 100*time.Millisecond).Should(BeNumerically(">=", 1))
+		})
+
+		It("Should log in again when the token is rejected", func() {
+			backend, err := NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			expiredToken := backend.client.Token()
+			server.RevokeToken(expiredToken)
+
+			data := map[string]any{"username": "admin", "password": "secret123"}
+			Expect(backend.WriteSecret(ctx, "bmc/us-east-1/bmc1/admin", data, secretbackend.WriteOptions{})).Error().NotTo(HaveOccurred())
+
+			Expect(backend.client.Token()).NotTo(Equal(expiredToken))
+			Expect(server.Count("PUT /v1/auth/approle/login")).To(Equal(2))
+			Expect(metrics.auths).To(HaveLen(2))
+
+			stored, ok := server.Secret("secret", "bmc/us-east-1/bmc1/admin")
+			Expect(ok).To(BeTrue())
+			Expect(stored).To(Equal(data))
+		})
+
+		It("Should return the error when logging in again fails", func() {
+			backend, err := NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			server.RevokeToken(backend.client.Token())
+			server.AddAppRole("bmc-operator", "rotated")
+
+			_, err = backend.ReadSecret(ctx, "bmc/us-east-1/bmc1/admin")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("re-authentication failed"))
+		})
+
+		It("Should log in again when checking the health with a rejected token", func() {
+			backend, err := NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(backend.Close)
+
+			Expect(backend.CheckHealth(ctx)).To(Succeed())
+
+			server.RevokeToken(backend.client.Token())
+			Expect(backend.CheckHealth(ctx)).To(Succeed())
+			Expect(server.Count("PUT /v1/auth/approle/login")).To(Equal(2))
+
+			server.RevokeToken(backend.client.Token())
+			server.AddAppRole("bmc-operator", "rotated")
+			Expect(backend.CheckHealth(ctx)).To(MatchError(secretbackend.ErrAuthentication))
+		})
+
+		It("Should revoke the token it logged in with when closed", func() {
+			backend, err := NewVaultBackend(newAppRoleConfig("bmc-operator", "secret-id"), metrics)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(backend.Close()).To(Succeed())
+			Expect(backend.Close()).To(Succeed())
+			Expect(server.Count("PUT /v1/auth/token/revoke-self")).To(Equal(1))
+			Expect(backend.CheckHealth(ctx)).To(HaveOccurred())
+		})
+
+		It("Should not revoke a configured token when closed", func() {
+			server.AddToken("static-token")
+			config := newAppRoleConfig("", "")
+			config.AuthMethod = "token"
+			config.Token = "static-token"
+			backend, err := NewVaultBackend(config, metrics)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(backend.Close()).To(Succeed())
+			Expect(server.Count("PUT /v1/auth/token/revoke-self")).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,449 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vaulttest provides an in-memory server speaking the subset of the
+// Vault HTTP API used by the Vault and OpenBao backends, for use in tests.
+package vaulttest
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is an in-memory Vault-compatible HTTP server
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	mounts   map[string]*mount
+	tokens   map[string]bool
+	appRoles map[string]string
+	tokenTTL int
+	logins   int
+
+	// Requests records "METHOD /v1/path" for every request served
+	Requests []string
+
+	// Namespaces records the namespace header of every request served, in the
+	// order of Requests
+	Namespaces []string
+}
+
+type mount struct {
+	version int
+	secrets map[string]*secret
+}
+
+type secret struct {
+	versions       []version
+	customMetadata map[string]any
+}
+
+type version struct {
+	data        map[string]any
+	createdTime time.Time
+	deleted     bool
+}
+
+// NewServer starts a new TLS server. Use CACert to obtain the PEM encoded
+// certificate for the backend TLS configuration.
+func NewServer() *Server {
+	s := &Server{
+		mounts:   make(map[string]*mount),
+		tokens:   make(map[string]bool),
+		appRoles: make(map[string]string),
+		tokenTTL: 3600,
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// CACert returns the PEM encoded server certificate
+func (s *Server) CACert() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}))
+}
+
+// AddMount adds a KV mount of the given version (1 or 2)
+func (s *Server) AddMount(path string, kvVersion int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mounts[path] = &mount{version: kvVersion, secrets: make(map[string]*secret)}
+}
+
+// AddToken registers a token accepted by the server
+func (s *Server) AddToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token] = true
+}
+
+// AddAppRole registers an AppRole login accepted at auth/approle/login
+func (s *Server) AddAppRole(roleID, secretID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appRoles[roleID] = secretID
+}
+
+// SetTokenTTL sets the TTL in seconds of tokens issued by logins and renewals
+func (s *Server) SetTokenTTL(ttl int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenTTL = ttl
+}
+
+// RevokeToken makes the server reject token, simulating its expiry
+func (s *Server) RevokeToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, token)
+}
+
+// Count returns how many served requests equal request ("METHOD /v1/path")
+func (s *Server) Count(request string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, r := range s.Requests {
+		if r == request {
+			count++
+		}
+	}
+	return count
+}
+
+// Secret returns the latest data stored at path in the given mount, or false
+// if there is none or the latest KV v2 version was deleted
+func (s *Server) Secret(mountPath, path string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.mounts[mountPath]
+	if !ok {
+		return nil, false
+	}
+	sec, ok := m.secrets[path]
+	if !ok || len(sec.versions) == 0 || sec.versions[len(sec.versions)-1].deleted {
+		return nil, false
+	}
+	return maps.Clone(sec.versions[len(sec.versions)-1].data), true
+}
+
+// Versions returns the number of KV v2 versions stored at path in the given
+// mount, including deleted ones
+func (s *Server) Versions(mountPath, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.mounts[mountPath]
+	if !ok {
+		return 0
+	}
+	if sec, ok := m.secrets[path]; ok {
+		return len(sec.versions)
+	}
+	return 0
+}
+
+// SetSecret stores data at path in the given mount as a new version
+func (s *Server) SetSecret(mountPath, path string, data map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mounts[mountPath].put(path, data)
+}
+
+// SetCustomMetadata replaces the KV v2 custom metadata stored at path in the given mount
+func (s *Server) SetCustomMetadata(mountPath, path string, metadata map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.mounts[mountPath]
+	sec, ok := m.secrets[path]
+	if !ok {
+		sec = &secret{}
+		m.secrets[path] = sec
+	}
+	sec.customMetadata = maps.Clone(metadata)
+}
+
+// CustomMetadata returns the KV v2 custom metadata stored at path in the given mount
+func (s *Server) CustomMetadata(mountPath, path string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.mounts[mountPath]
+	if !ok {
+		return nil, false
+	}
+	sec, ok := m.secrets[path]
+	if !ok {
+		return nil, false
+	}
+	return maps.Clone(sec.customMetadata), true
+}
+
+func (m *mount) put(path string, data map[string]any) *version {
+	sec, ok := m.secrets[path]
+	if !ok || m.version == 1 {
+		sec = &secret{}
+		m.secrets[path] = sec
+	}
+	sec.versions = append(sec.versions, version{data: maps.Clone(data), createdTime: time.Now().UTC()})
+	return &sec.versions[len(sec.versions)-1]
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	s.Requests = append(s.Requests, r.Method+" /v1/"+path)
+	s.Namespaces = append(s.Namespaces, r.Header.Get("X-Vault-Namespace"))
+
+	switch {
+	case path == "sys/mounts":
+		s.handleMounts(w)
+		return
+	case path == "auth/token/lookup-self":
+		if !s.tokens[r.Header.Get("X-Vault-Token")] {
+			writeError(w, http.StatusForbidden, "permission denied")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"ttl": 3600, "renewable": false}})
+		return
+	case path == "auth/token/renew-self":
+		token := r.Header.Get("X-Vault-Token")
+		if !s.tokens[token] {
+			writeError(w, http.StatusForbidden, "permission denied")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"auth": s.tokenAuth(token)})
+		return
+	case path == "auth/token/revoke-self":
+		token := r.Header.Get("X-Vault-Token")
+		if !s.tokens[token] {
+			writeError(w, http.StatusForbidden, "permission denied")
+			return
+		}
+		delete(s.tokens, token)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case path == "auth/approle/login":
+		s.handleAppRoleLogin(w, r)
+		return
+	}
+
+	if !s.tokens[r.Header.Get("X-Vault-Token")] {
+		writeError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	for mountPath, m := range s.mounts {
+		if !strings.HasPrefix(path, mountPath+"/") {
+			continue
+		}
+		rest := strings.TrimPrefix(path, mountPath+"/")
+		if m.version == 2 {
+			s.handleKVv2(w, r, m, rest)
+		} else {
+			s.handleKVv1(w, r, m, rest)
+		}
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "no handler for route")
+}
+
+func (s *Server) handleMounts(w http.ResponseWriter) {
+	data := make(map[string]any, len(s.mounts))
+	for mountPath, m := range s.mounts {
+		data[mountPath+"/"] = map[string]any{
+			"type":    "kv",
+			"options": map[string]any{"version": strconv.Itoa(m.version)},
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+func (s *Server) handleAppRoleLogin(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		RoleID   string `json:"role_id"`
+		SecretID string `json:"secret_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	secretID, ok := s.appRoles[body.RoleID]
+	if !ok || secretID != body.SecretID {
+		writeError(w, http.StatusBadRequest, "invalid role or secret ID")
+		return
+	}
+
+	s.logins++
+	token := fmt.Sprintf("s.approle-%s-%d", body.RoleID, s.logins)
+	s.tokens[token] = true
+	writeJSON(w, http.StatusOK, map[string]any{"auth": s.tokenAuth(token)})
+}
+
+func (s *Server) tokenAuth(token string) map[string]any {
+	return map[string]any{
+		"client_token":   token,
+		"lease_duration": s.tokenTTL,
+		"renewable":      true,
+	}
+}
+
+func (s *Server) handleKVv1(w http.ResponseWriter, r *http.Request, m *mount, path string) {
+	switch r.Method {
+	case http.MethodGet:
+		sec, ok := m.secrets[path]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]any{"errors": []string{}})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"data": sec.versions[len(sec.versions)-1].data})
+	case http.MethodPut, http.MethodPost:
+		var data map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		m.put(path, data)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(m.secrets, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "unsupported method")
+	}
+}
+
+func (s *Server) handleKVv2(w http.ResponseWriter, r *http.Request, m *mount, path string) {
+	switch {
+	case strings.HasPrefix(path, "data/"):
+		path = strings.TrimPrefix(path, "data/")
+		switch r.Method {
+		case http.MethodGet:
+			sec, ok := m.secrets[path]
+			if !ok || len(sec.versions) == 0 || sec.versions[len(sec.versions)-1].deleted {
+				writeJSON(w, http.StatusNotFound, map[string]any{"errors": []string{}})
+				return
+			}
+			v := sec.versions[len(sec.versions)-1]
+			metadata := versionMetadata(len(sec.versions), v)
+			metadata["custom_metadata"] = sec.customMetadata
+			writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{
+				"data":     v.data,
+				"metadata": metadata,
+			}})
+		case http.MethodPut, http.MethodPost:
+			var body struct {
+				Data    map[string]any `json:"data"`
+				Options struct {
+					CAS *int `json:"cas"`
+				} `json:"options"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if body.Options.CAS != nil {
+				current := 0
+				if sec, ok := m.secrets[path]; ok {
+					current = len(sec.versions)
+				}
+				if *body.Options.CAS != current {
+					writeError(w, http.StatusBadRequest, "check-and-set parameter did not match the current version")
+					return
+				}
+			}
+			v := m.put(path, body.Data)
+			writeJSON(w, http.StatusOK, map[string]any{"data": versionMetadata(len(m.secrets[path].versions), *v)})
+		case http.MethodDelete:
+			if sec, ok := m.secrets[path]; ok && len(sec.versions) > 0 {
+				sec.versions[len(sec.versions)-1].deleted = true
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "unsupported method")
+		}
+	case strings.HasPrefix(path, "metadata/"):
+		path = strings.TrimPrefix(path, "metadata/")
+		switch r.Method {
+		case http.MethodPut, http.MethodPost, http.MethodPatch:
+			var body struct {
+				CustomMetadata map[string]any `json:"custom_metadata"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			sec, ok := m.secrets[path]
+			if r.Method == http.MethodPatch {
+				if !ok {
+					writeJSON(w, http.StatusNotFound, map[string]any{"errors": []string{}})
+					return
+				}
+				if sec.customMetadata == nil {
+					sec.customMetadata = make(map[string]any)
+				}
+				maps.Copy(sec.customMetadata, body.CustomMetadata)
+			} else {
+				if !ok {
+					sec = &secret{}
+					m.secrets[path] = sec
+				}
+				sec.customMetadata = body.CustomMetadata
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			delete(m.secrets, path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "unsupported method")
+		}
+	default:
+		writeError(w, http.StatusNotFound, "no handler for route")
+	}
+}
+
+func versionMetadata(versionNumber int, v version) map[string]any {
+	return map[string]any{
+		"version":       versionNumber,
+		"created_time":  v.createdTime.Format(time.RFC3339Nano),
+		"deletion_time": "",
+		"destroyed":     false,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{"errors": []string{message}})
+}
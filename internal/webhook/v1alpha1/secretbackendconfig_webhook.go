@@ -0,0 +1,109 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+	"github.com/ironcore-dev/bmc-secret-operator/internal/secretbackend"
+)
+
+// log is for logging in this package.
+var secretbackendconfiglog = logf.Log.WithName("secretbackendconfig-resource")
+
+// SetupSecretBackendConfigWebhookWithManager registers the webhooks for SecretBackendConfig in the manager
+func SetupSecretBackendConfigWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &configv1alpha1.SecretBackendConfig{}).
+		WithValidator(&SecretBackendConfigCustomValidator{}).
+		WithDefaulter(&SecretBackendConfigCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-config-metal-ironcore-dev-v1alpha1-secretbackendconfig,mutating=true,failurePolicy=fail,sideEffects=None,groups=config.metal.ironcore.dev,resources=secretbackendconfigs,verbs=create;update,versions=v1alpha1,name=msecretbackendconfig-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecretBackendConfigCustomDefaulter sets the defaults the operator assumes for
+// unset fields, so they are visible on the stored object
+type SecretBackendConfigCustomDefaulter struct{}
+
+// Default implements admission.Defaulter
+func (d *SecretBackendConfigCustomDefaulter) Default(_ context.Context, config *configv1alpha1.SecretBackendConfig) error {
+	secretbackendconfiglog.V(1).Info("Defaulting for SecretBackendConfig", "name", config.GetName())
+
+	secretbackend.SetDefaults(config)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-config-metal-ironcore-dev-v1alpha1-secretbackendconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=config.metal.ironcore.dev,resources=secretbackendconfigs,verbs=create;update,versions=v1alpha1,name=vsecretbackendconfig-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecretBackendConfigCustomValidator rejects SecretBackendConfigs that would make
+// every sync fail, e.g. path templates that cannot be rendered or conflicting engines
+type SecretBackendConfigCustomValidator struct{}
+
+// ValidateCreate implements admission.Validator
+func (v *SecretBackendConfigCustomValidator) ValidateCreate(_ context.Context, config *configv1alpha1.SecretBackendConfig) (admission.Warnings, error) {
+	secretbackendconfiglog.V(1).Info("Validation for SecretBackendConfig upon creation", "name", config.GetName())
+
+	return nil, validate(config)
+}
+
+// ValidateUpdate implements admission.Validator
+func (v *SecretBackendConfigCustomValidator) ValidateUpdate(_ context.Context, _, config *configv1alpha1.SecretBackendConfig) (admission.Warnings, error) {
+	secretbackendconfiglog.V(1).Info("Validation for SecretBackendConfig upon update", "name", config.GetName())
+
+	return nil, validate(config)
+}
+
+// ValidateDelete implements admission.Validator. Deletion is always allowed,
+// the operator falls back to the remaining configs or environment variables.
+func (v *SecretBackendConfigCustomValidator) ValidateDelete(_ context.Context, _ *configv1alpha1.SecretBackendConfig) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate returns an Invalid error listing every problem of the config. Configs whose
+// path templates render the same path for distinct BMCs are only denied here, stored
+// ones keep syncing.
+func validate(config *configv1alpha1.SecretBackendConfig) error {
+	var errs []error
+	for _, err := range []error{secretbackend.ValidateConfig(config), secretbackend.ValidateDistinctPaths(config)} {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = append(errs, joined.Unwrap()...)
+		} else if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	for _, err := range errs {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), field.OmitValueType{}, err.Error()))
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: configv1alpha1.GroupVersion.Group, Kind: "SecretBackendConfig"},
+		config.Name,
+		allErrs,
+	)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/ironcore-dev/bmc-secret-operator/api/v1alpha1"
+)
+
+func TestSecretBackendConfigWebhook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SecretBackendConfig Webhook Suite")
+}
+
+var _ = Describe("SecretBackendConfig Webhook", func() {
+	var (
+		ctx       context.Context
+		config    *configv1alpha1.SecretBackendConfig
+		validator SecretBackendConfigCustomValidator
+		defaulter SecretBackendConfigCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		config = &configv1alpha1.SecretBackendConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: configv1alpha1.SecretBackendConfigSpec{
+				Backend: "vault",
+				VaultConfig: &configv1alpha1.VaultConfig{
+					Address:        "https://vault.example.com:8200",
+					KubernetesAuth: &configv1alpha1.KubernetesAuthConfig{Role: "bmc-operator"},
+				},
+			},
+		}
+	})
+
+	Context("When creating or updating a SecretBackendConfig under the defaulting webhook", func() {
+		It("Should apply the defaults", func() {
+			Expect(defaulter.Default(ctx, config)).To(Succeed())
+			Expect(config.Spec.PathTemplate).To(Equal("bmc/{{.Region}}/{{.Hostname}}/{{.Username}}"))
+			Expect(config.Spec.VaultConfig.AuthMethod).To(Equal("kubernetes"))
+			Expect(config.Spec.VaultConfig.MountPath).To(Equal("secret"))
+			Expect(config.Spec.VaultConfig.KubernetesAuth.Path).To(Equal("kubernetes"))
+		})
+
+		It("Should keep the values that are set", func() {
+			config.Spec.PathTemplate = "servers/{{.Hostname}}"
+			config.Spec.VaultConfig.MountPath = "bmc"
+
+			Expect(defaulter.Default(ctx, config)).To(Succeed())
+			Expect(config.Spec.PathTemplate).To(Equal("servers/{{.Hostname}}"))
+			Expect(config.Spec.VaultConfig.MountPath).To(Equal("bmc"))
+		})
+	})
+
+	Context("When creating or updating a SecretBackendConfig under the validating webhook", func() {
+		It("Should admit a valid config", func() {
+			Expect(validator.ValidateCreate(ctx, config)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateUpdate(ctx, config, config)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a config without the configuration of its backend", func() {
+			config.Spec.VaultConfig = nil
+
+			_, err := validator.ValidateCreate(ctx, config)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("vaultConfig is required when backend is vault")))
+		})
+
+		It("Should list every problem of the config", func() {
+			config.Spec.PathTemplate = "bmc/{{.Hostname"
+			config.Spec.VaultConfig.SecretEngines = []configv1alpha1.SecretEngineConfig{
+				{Name: "team-a", MountPath: "team-a", SyncLabel: "team=a"},
+				{Name: "team-a", MountPath: "team-b", SyncLabel: "team=b"},
+			}
+
+			_, err := validator.ValidateUpdate(ctx, config, config)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.(*apierrors.StatusError).ErrStatus.Details.Causes).To(HaveLen(2))
+			Expect(err).To(MatchError(ContainSubstring("failed to parse path template")))
+			Expect(err).To(MatchError(ContainSubstring("duplicate engine name team-a")))
+		})
+
+		It("Should deny a path template rendering the same path for distinct BMCs", func() {
+			config.Spec.PathTemplate = "bmc/{{.Region}}/{{.Username}}"
+
+			_, err := validator.ValidateCreate(ctx, config)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("path template renders the same path bmc/us-east-1/admin for distinct BMCs")))
+
+			_, err = validator.ValidateUpdate(ctx, config, config)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("Should always admit a deletion", func() {
+			config.Spec.VaultConfig = nil
+
+			Expect(validator.ValidateDelete(ctx, config)).Error().NotTo(HaveOccurred())
+		})
+	})
+})